@@ -9,6 +9,14 @@ import (
 	"strings"
 )
 
+const (
+	CreatedAtTag  = "createdAt"
+	UpdatedAtTag  = "updatedAt"
+	SoftDeleteTag = "softDelete"
+	VersionTag    = "version"
+	TenantTag     = "tenant"
+)
+
 const (
 	DBFilename        = "db.json"
 	ModelFilename     = "models.go"
@@ -31,6 +39,30 @@ func Build(makeFile bool, env string, adapter string, dbUrl string, schemaName s
 	return schema
 }
 
+const (
+	intKind = iota
+	floatKind
+	boolKind
+	timeKind
+	stringKind
+)
+
+// 컬럼이 모델에서 어떤 종류의 값으로 변환되는지 반환합니다. 모델과 TypeScript 타입이 같은 규칙을 따르도록 공유합니다.
+func columnKind(column *Column) int {
+	switch {
+	case strings.HasPrefix(column.Type, "int"):
+		return intKind
+	case strings.HasPrefix(column.Type, "float"):
+		return floatKind
+	case strings.HasPrefix(column.Type, "tinyint(1)"):
+		return boolKind
+	case strings.HasPrefix(column.Type, "date") || strings.HasPrefix(column.Type, "time"):
+		return timeKind
+	}
+
+	return stringKind
+}
+
 func SaveToFile(relativePath string, body []byte, overwrite bool) {
 	pwd, err := os.Getwd()
 	Check(err)
@@ -57,7 +89,7 @@ func printSchemaFile(schema *Schema) string {
 	var mapTemplate, newTemplate, newFuncTemplate, modelTemplate string
 	for _, table := range schema.Tables {
 		tableName := Classify(table.Name)
-		tableString := fmt.Sprintf("type %v struct {\n  FulFilled map[string]interface{} `json\"-\",structs:\"-\",gorm:\"-\"`\n", tableName)
+		tableString := fmt.Sprintf("type %v struct {\n  FulFilled map[string]interface{} `json:\"-\" structs:\"-\" gorm:\"-\"`\n", tableName)
 
 		for _, column := range table.Columns {
 			columnType := "string"
@@ -69,25 +101,26 @@ func printSchemaFile(schema *Schema) string {
 			gormTag := fmt.Sprintf("type:%v;column:%v", column.Type, column.Name)
 			jsonTag := CamelCase(column.Name)
 
-			if strings.HasPrefix(column.Type, "int") {
+			switch columnKind(column) {
+			case intKind:
 				columnType = "int64"
 
 				if column.Null {
 					columnType = "NullInt64"
 				}
-			} else if strings.HasPrefix(column.Type, "float") {
+			case floatKind:
 				columnType = "float64"
 
 				if column.Null {
 					columnType = "NullFloat64"
 				}
-			} else if strings.HasPrefix(column.Type, "tinyint(1)") {
+			case boolKind:
 				columnType = "bool"
 
 				if column.Null {
 					columnType = "NullBool"
 				}
-			} else if strings.HasPrefix(column.Type, "date") || strings.HasPrefix(column.Type, "time") {
+			case timeKind:
 				columnType = "*time.Time"
 			}
 
@@ -101,9 +134,24 @@ func printSchemaFile(schema *Schema) string {
 				gormTag += ";not null"
 			}
 
+			// 서버에서 관리하는 컬럼은 커스텀 코드에서 구분할 수 있도록 octopus 태그를 추가한다. 태그의 키들은 reflect.StructTag 가 읽을 수 있도록 공백으로 구분한다.
+			octopusTag := ""
+			switch column.Name {
+			case table.CreatedAt:
+				octopusTag = fmt.Sprintf(" octopus:\"%v\"", CreatedAtTag)
+			case table.UpdatedAt:
+				octopusTag = fmt.Sprintf(" octopus:\"%v\"", UpdatedAtTag)
+			case table.SoftDelete:
+				octopusTag = fmt.Sprintf(" octopus:\"%v\"", SoftDeleteTag)
+			case table.Version:
+				octopusTag = fmt.Sprintf(" octopus:\"%v\"", VersionTag)
+			case table.Tenant:
+				octopusTag = fmt.Sprintf(" octopus:\"%v\"", TenantTag)
+			}
+
 			tableString += fmt.Sprintf(
-				"  %[1]v %[2]v `json:\"%[3]v\",structs:\"%[3]v\",gorm:\"%[4]v\"`\n",
-				columnName, columnType, jsonTag, gormTag,
+				"  %[1]v %[2]v `json:\"%[3]v\" structs:\"%[3]v\" gorm:\"%[4]v\"%[5]v`\n",
+				columnName, columnType, jsonTag, gormTag, octopusTag,
 			)
 		}
 
@@ -191,5 +239,10 @@ func (r NullString) String() string {
 	return r.NullString.String
 }`
 
+	// 날짜 컬럼이 없는 스키마에서는 사용하지 않는 time 패키지를 가져오지 않는다.
+	if !strings.Contains(modelTemplate, "time.Time") {
+		template = strings.Replace(template, "  \"time\"\n", "", 1)
+	}
+
 	return fmt.Sprintf(template, mapTemplate, modelTemplate, newFuncTemplate, nullValueTemplate, newTemplate)
 }
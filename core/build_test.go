@@ -0,0 +1,65 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestPrintSchemaFile_Tags(t *testing.T) {
+	schema := &Schema{
+		Tables: map[string]*Table{
+			"book": {
+				Name:       "book",
+				CreatedAt:  "created_at",
+				UpdatedAt:  "updated_at",
+				SoftDelete: "deleted_at",
+				Version:    "lock_version",
+				Tenant:     "tenant_id",
+				Columns: map[string]*Column{
+					"id":          {Name: "id", Type: "int(11)", Key: "PRI"},
+					"createdAt":   {Name: "created_at", Type: "datetime"},
+					"updatedAt":   {Name: "updated_at", Type: "datetime"},
+					"deletedAt":   {Name: "deleted_at", Type: "datetime", Null: true},
+					"lockVersion": {Name: "lock_version", Type: "int(11)"},
+					"tenantId":    {Name: "tenant_id", Type: "int(11)"},
+				},
+			},
+		},
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "models.go", printSchemaFile(schema), 0)
+	assert.Nil(t, err)
+
+	tags := map[string]reflect.StructTag{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if field, ok := node.(*ast.Field); ok && field.Tag != nil && len(field.Names) == 1 {
+			tag, err := strconv.Unquote(field.Tag.Value)
+			assert.Nil(t, err)
+			tags[field.Names[0].Name] = reflect.StructTag(tag)
+		}
+
+		return true
+	})
+
+	assert.Equal(t, "-", tags["FulFilled"].Get("json"))
+	assert.Equal(t, "-", tags["FulFilled"].Get("gorm"))
+	assert.Equal(t, "id", tags["Id"].Get("json"))
+	assert.Equal(t, "id", tags["Id"].Get("structs"))
+	assert.Equal(t, "type:int(11);column:id;primary_key;not null", tags["Id"].Get("gorm"))
+	assert.Equal(t, "", tags["Id"].Get("octopus"))
+
+	for field, expected := range map[string]string{
+		"CreatedAt":   CreatedAtTag,
+		"UpdatedAt":   UpdatedAtTag,
+		"DeletedAt":   SoftDeleteTag,
+		"LockVersion": VersionTag,
+		"TenantId":    TenantTag,
+	} {
+		assert.Equal(t, expected, tags[field].Get("octopus"), field)
+	}
+}
@@ -0,0 +1,357 @@
+package core
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type (
+	// 설정 파일의 모든 오류입니다. 각 오류는 가능한 경우 줄 번호를 포함합니다.
+	ConfigError struct {
+		Filename string
+		Errors   []string
+	}
+)
+
+var (
+	interpolationRegex = regexp.MustCompile(`\$?\$\{([^}]*)\}`)
+	lineNumberRegex    = regexp.MustCompile(`^line (\d+): (.*)$`)
+	unknownFieldRegex  = regexp.MustCompile(`field (\S+) not found in type .*$`)
+	supportedAdapters  = []string{"mysql"}
+)
+
+const (
+	DefaultPort              = "3306"
+	DefaultMaxConnectionPool = 100
+	filePrefix               = "file:"
+)
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("The `%v` file is not valid.\n  %v", e.Filename, strings.Join(e.Errors, "\n  "))
+}
+
+// 프로젝트의 설정 파일을 읽어 환경 변수와 파일의 값으로 치환한 후 엄격하게 검증합니다.
+// 모든 오류를 모아 ConfigError 로 반환하며, 설정 파일이 없다면 빈 설정을 반환합니다.
+func LoadConfig() (*Config, error) {
+	file, err := ioutil.ReadFile(path.Join(GetProjectDir(), ConfigFilename))
+
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfig(file)
+}
+
+// 설정 파일의 내용을 해석하고 검증합니다.
+// YAML 을 먼저 해석한 후 문자열 값만 치환하므로 치환된 값이 문서의 구조나 오류의 줄 번호를 바꾸지 않습니다.
+func ParseConfig(file []byte) (*Config, error) {
+	configError := &ConfigError{Filename: ConfigFilename}
+	config := &Config{lines: strings.Split(string(file), "\n")}
+
+	if err := yaml.UnmarshalStrict(file, config); err != nil {
+		if typeError, ok := err.(*yaml.TypeError); ok {
+			for _, message := range typeError.Errors {
+				configError.add(0, "%v", unknownFieldRegex.ReplaceAllString(message, "`$1` is not a known setting."))
+			}
+		} else {
+			configError.add(0, "%v", err)
+		}
+	}
+
+	config.interpolate(reflect.ValueOf(config).Elem(), nil, configError)
+	configError.Errors = append(configError.Errors, config.validate()...)
+
+	if len(configError.Errors) > 0 {
+		return nil, configError
+	}
+
+	return config, nil
+}
+
+// `${VAR}`, `${VAR:-default}`, `${file:/path/to/secret}` 형태의 값을 치환합니다.
+// `$${VAR}`는 치환하지 않고 `${VAR}`로 남깁니다.
+func Interpolate(value string) (string, error) {
+	var firstError error
+
+	interpolated := interpolationRegex.ReplaceAllStringFunc(value, func(matched string) string {
+		if strings.HasPrefix(matched, "$$") {
+			return matched[1:]
+		}
+
+		resolved, err := resolveVariable(interpolationRegex.FindStringSubmatch(matched)[1])
+
+		if err != nil && firstError == nil {
+			firstError = err
+		}
+
+		return resolved
+	})
+
+	return interpolated, firstError
+}
+
+// 설정의 모든 문자열 값을 YAML 의 키 경로를 따라가며 치환합니다. 오류는 해당 키의 줄 번호와 함께 기록합니다.
+// 다른 환경의 비밀값이 없다고 실패하지 않도록 `database.<env>`의 오류는 환경별로 모아 ValidateDatabase 에서 보고합니다.
+func (c *Config) interpolate(v reflect.Value, keys []string, e *ConfigError) {
+	switch v.Kind() {
+	case reflect.String:
+		interpolated, err := Interpolate(v.String())
+
+		if err != nil {
+			target := e
+
+			if len(keys) > 1 && keys[0] == "database" {
+				if c.databaseErrors == nil {
+					c.databaseErrors = map[string]*ConfigError{}
+				}
+
+				if c.databaseErrors[keys[1]] == nil {
+					c.databaseErrors[keys[1]] = &ConfigError{Filename: ConfigFilename}
+				}
+
+				target = c.databaseErrors[keys[1]]
+			}
+
+			target.add(findLine(c.lines, keys...), "%v: %v", strings.Join(keys, "."), err)
+		}
+
+		v.SetString(interpolated)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+
+			if field.PkgPath != "" {
+				continue
+			}
+
+			c.interpolate(v.Field(i), append(keys, yamlKey(field)), e)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			c.interpolate(v.Index(i), keys, e)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			// 맵의 값은 주소를 가질 수 없으므로 복사하여 치환한 후 다시 저장합니다.
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+
+			c.interpolate(value, append(keys, fmt.Sprintf("%v", key.Interface())), e)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
+
+// yaml.v2 와 같은 규칙으로 필드의 키를 정합니다. 태그가 없다면 필드 이름을 소문자로 바꾼 것입니다.
+func yamlKey(field reflect.StructField) string {
+	if tag := strings.Split(field.Tag.Get("yaml"), ",")[0]; tag != "" {
+		return tag
+	}
+
+	return strings.ToLower(field.Name)
+}
+
+func resolveVariable(expression string) (string, error) {
+	if strings.HasPrefix(expression, filePrefix) {
+		filename := strings.TrimPrefix(expression, filePrefix)
+		b, err := ioutil.ReadFile(filename)
+
+		if err != nil {
+			return "", fmt.Errorf("Can not read the secret file `%v`.", filename)
+		}
+
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+
+	name, defaultValue, hasDefault := expression, "", false
+
+	if index := strings.Index(expression, ":-"); index >= 0 {
+		name, defaultValue, hasDefault = expression[:index], expression[index+2:], true
+	}
+
+	if value, exist := os.LookupEnv(name); exist && (value != "" || !hasDefault) {
+		return value, nil
+	}
+
+	if hasDefault {
+		return defaultValue, nil
+	}
+
+	return "", fmt.Errorf("The environment variable `%v` is not set.", name)
+}
+
+// ------------------------------
+// Validation
+// ------------------------------
+
+func (c *Config) validate() []string {
+	e := &ConfigError{}
+	lines := c.lines
+
+	if c.Server.DrainTimeout < 0 || c.Server.DrainDelay < 0 {
+		e.add(findLine(lines, "server"), "server values must not be negative.")
+	}
+
+	if c.Paging.Limit < 0 || c.Paging.MaxLimit < 0 || c.Paging.Offset < 0 {
+		e.add(findLine(lines, "paging"), "paging values must not be negative.")
+	}
+
+	if c.Paging.MaxLimit > 0 && c.Paging.Limit > c.Paging.MaxLimit {
+		e.add(findLine(lines, "paging", "limit"), "paging.limit must not be greater than paging.maxLimit.")
+	}
+
+	if c.Batch.Concurrency < 0 || c.Batch.MaxSize < 0 {
+		e.add(findLine(lines, "batch"), "batch values must not be negative.")
+	}
+
+	if c.Export.ChunkSize < 0 {
+		e.add(findLine(lines, "export"), "export.chunkSize must not be negative.")
+	}
+
+	if c.Rest.Secret != "" && c.Rest.UserHeader != "" {
+		e.add(findLine(lines, "rest"), "rest.secret and rest.userHeader can not be used together.")
+	}
+
+	if c.Audit.Table != "" && c.Audit.File != "" {
+		e.add(findLine(lines, "audit"), "audit.table and audit.file can not be used together.")
+	}
+
+	switch c.Tracing.Exporter {
+	case "":
+	case "otlp":
+		if c.Tracing.Endpoint == "" {
+			e.add(findLine(lines, "tracing"), "tracing.endpoint is required for the otlp exporter.")
+		}
+	case "file":
+		if c.Tracing.File == "" {
+			e.add(findLine(lines, "tracing"), "tracing.file is required for the file exporter.")
+		}
+	default:
+		e.add(findLine(lines, "tracing", "exporter"), "tracing.exporter must be one of otlp, file.")
+	}
+
+	for _, env := range sortedKeys(c.Database) {
+		database := c.Database[env]
+
+		if database.Adapter != "" && !Contains(supportedAdapters, database.Adapter) {
+			e.add(findLine(lines, "database", env, "adapter"), "database.%v.adapter `%v` is not supported.", env, database.Adapter)
+		}
+
+		for i, replica := range database.Replicas {
+			if replica.Database == "" {
+				e.add(findLine(lines, "database", env, "replicas"), "database.%v.replicas[%v].database is required.", env, i)
+			}
+		}
+	}
+
+	return e.Errors
+}
+
+// 환경의 데이터베이스 접속 정보가 모두 채워져 있는지 검증합니다. 템플릿으로만 쓰이는 환경이 있으므로
+// 실제로 연결하려는 환경만 검증하며, 비어있는 접속 정보를 임의의 값으로 추측하지 않습니다.
+// 환경 변수를 치환하지 못한 접속 정보도 이때 함께 보고합니다.
+func (c *Config) ValidateDatabase(env string) error {
+	e := &ConfigError{Filename: ConfigFilename}
+	database, exist := c.Database[env]
+
+	if !exist {
+		e.add(findLine(c.lines, "database"), "database.%v is not configured.", env)
+		return e
+	}
+
+	if interpolationError := c.databaseErrors[env]; interpolationError != nil {
+		e.Errors = append(e.Errors, interpolationError.Errors...)
+	}
+
+	required := map[string]string{
+		"adapter":  database.Adapter,
+		"schema":   database.Schema,
+		"charset":  database.Charset,
+		"database": database.Database,
+		"username": database.Username,
+	}
+
+	for _, key := range []string{"adapter", "schema", "charset", "database", "username"} {
+		if required[key] == "" {
+			e.add(findLine(c.lines, "database", env), "database.%v.%v is required.", env, key)
+		}
+	}
+
+	if len(e.Errors) > 0 {
+		return e
+	}
+
+	return nil
+}
+
+func (e *ConfigError) add(line int, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+
+	if matched := lineNumberRegex.FindStringSubmatch(message); matched != nil {
+		message = fmt.Sprintf("%v:%v: %v", ConfigFilename, matched[1], matched[2])
+	} else if line > 0 {
+		message = fmt.Sprintf("%v:%v: %v", ConfigFilename, line, message)
+	} else {
+		message = fmt.Sprintf("%v: %v", ConfigFilename, message)
+	}
+
+	e.Errors = append(e.Errors, message)
+}
+
+// 들여쓰기를 따라 키의 경로를 찾아 줄 번호를 반환합니다. 찾지 못한 키는 가장 가까운 상위 키의 줄 번호를 사용합니다.
+func findLine(lines []string, keys ...string) int {
+	found, start, indent := 0, 0, -1
+
+	for _, key := range keys {
+		matched := false
+
+		for i := start; i < len(lines); i++ {
+			trimmed := strings.TrimSpace(lines[i])
+
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+
+			current := len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+
+			// 상위 키의 블록을 벗어났다면 더 이상 찾지 않습니다.
+			if current <= indent {
+				break
+			}
+
+			if strings.HasPrefix(trimmed, key+":") {
+				found, start, indent, matched = i+1, i+1, current, true
+				break
+			}
+		}
+
+		if !matched {
+			break
+		}
+	}
+
+	return found
+}
+
+func sortedKeys(m map[string]DatabaseConfig) []string {
+	var keys []string
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	os.Setenv("OCTOPUS_TEST_HOST", "10.0.0.1")
+	defer os.Unsetenv("OCTOPUS_TEST_HOST")
+
+	f, err := ioutil.TempFile("", "secret")
+	assert.Nil(t, err)
+	defer os.Remove(f.Name())
+	f.WriteString("s3cret\n")
+	f.Close()
+
+	for value, expected := range map[string]string{
+		"${OCTOPUS_TEST_HOST}":                                 "10.0.0.1",
+		"${OCTOPUS_TEST_PORT:-3307}":                           "3307",
+		"${file:" + f.Name() + "}":                             "s3cret",
+		"$${OCTOPUS_TEST_HOST}":                                "${OCTOPUS_TEST_HOST}",
+		"tcp(${OCTOPUS_TEST_HOST}:${OCTOPUS_TEST_PORT:-3307})": "tcp(10.0.0.1:3307)",
+	} {
+		interpolated, err := Interpolate(value)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, interpolated, value)
+	}
+
+	_, err = Interpolate("${OCTOPUS_TEST_MISSING}")
+	assert.EqualError(t, err, "The environment variable `OCTOPUS_TEST_MISSING` is not set.")
+
+	_, err = Interpolate("${file:/not/exist}")
+	assert.EqualError(t, err, "Can not read the secret file `/not/exist`.")
+}
+
+func TestParseConfig_Interpolate(t *testing.T) {
+	// 따옴표, 콜론, 주석 기호, 중괄호와 줄바꿈을 가진 값도 문서의 구조를 바꾸지 않고 그대로 들어갑니다.
+	secret := "p\"a: ss # {x}\nline2"
+	os.Setenv("OCTOPUS_TEST_PASSWORD", secret)
+	defer os.Unsetenv("OCTOPUS_TEST_PASSWORD")
+
+	f, err := ioutil.TempFile("", "secret")
+	assert.Nil(t, err)
+	defer os.Remove(f.Name())
+	f.WriteString("first\nsecond\nthird\n")
+	f.Close()
+
+	config, err := ParseConfig([]byte(`database:
+  default: &default
+    adapter: mysql
+    password: ${OCTOPUS_TEST_PASSWORD}
+    username: ${file:` + f.Name() + `}
+  test:
+    <<: *default
+    schema: $${LITERAL}
+# comment: ${OCTOPUS_TEST_MISSING}
+tracing:
+  headers:
+    authorization: Bearer ${OCTOPUS_TEST_PASSWORD}
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, secret, config.Database["test"].Password)
+	assert.Equal(t, "first\nsecond\nthird", config.Database["test"].Username)
+	assert.Equal(t, "${LITERAL}", config.Database["test"].Schema)
+	assert.Equal(t, "Bearer "+secret, config.Tracing.Headers["authorization"])
+
+	// 여러 줄의 값으로 치환되더라도 뒤따르는 오류의 줄 번호는 원래 파일의 것입니다.
+	_, err = ParseConfig([]byte(`database:
+  test:
+    username: ${file:` + f.Name() + `}
+    adapter: postgres
+tracing:
+  endpoint: ${OCTOPUS_TEST_MISSING}
+`))
+	assert.Equal(t, []string{
+		"config.yaml:6: tracing.endpoint: The environment variable `OCTOPUS_TEST_MISSING` is not set.",
+		"config.yaml:4: database.test.adapter `postgres` is not supported.",
+	}, err.(*ConfigError).Errors)
+
+	// 사용하지 않는 환경의 비밀값이 없어도 설정을 읽을 수 있으며, 해당 환경에 연결할 때 오류를 보고합니다.
+	config, err = ParseConfig([]byte(`database:
+  test:
+    adapter: mysql
+    charset: utf8
+    database: spoon
+    schema: spoon_test
+    username: ${file:` + f.Name() + `}
+  production:
+    password: ${OCTOPUS_TEST_MISSING}
+`))
+	assert.Nil(t, err)
+	assert.Nil(t, config.ValidateDatabase("test"))
+	assert.Equal(t, []string{
+		"config.yaml:9: database.production.password: The environment variable `OCTOPUS_TEST_MISSING` is not set.",
+		"config.yaml:8: database.production.adapter is required.",
+		"config.yaml:8: database.production.schema is required.",
+		"config.yaml:8: database.production.charset is required.",
+		"config.yaml:8: database.production.database is required.",
+		"config.yaml:8: database.production.username is required.",
+	}, config.ValidateDatabase("production").(*ConfigError).Errors)
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig([]byte(`paging:
+  limit: 10
+export:
+  chunkSize: 200
+database:
+  test:
+    adapter: mysql
+    schema: spoon_test
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, 10, config.Paging.Limit)
+	assert.Equal(t, 200, config.Export.ChunkSize)
+
+	_, err = ParseConfig([]byte(`server:
+  drainDelay: -1
+paging:
+  limit: 100
+  maxLimit: 50
+  maxLimt: 50
+tracing:
+  exporter: zipkin
+database:
+  test:
+    adapter: postgres
+    password: ${OCTOPUS_TEST_MISSING}
+`))
+	assert.Equal(t, []string{
+		"config.yaml:6: `maxLimt` is not a known setting.",
+		"config.yaml:1: server values must not be negative.",
+		"config.yaml:4: paging.limit must not be greater than paging.maxLimit.",
+		"config.yaml:8: tracing.exporter must be one of otlp, file.",
+		"config.yaml:11: database.test.adapter `postgres` is not supported.",
+	}, err.(*ConfigError).Errors)
+}
+
+func TestConfig_ValidateDatabase(t *testing.T) {
+	config, err := ParseConfig([]byte(`database:
+  default: &default
+    adapter: mysql
+    charset: utf8
+  test:
+    <<: *default
+    schema: spoon_test
+`))
+	assert.Nil(t, err)
+
+	err = config.ValidateDatabase("test")
+	assert.Equal(t, []string{
+		"config.yaml:5: database.test.database is required.",
+		"config.yaml:5: database.test.username is required.",
+	}, err.(*ConfigError).Errors)
+
+	err = config.ValidateDatabase("production")
+	assert.Equal(t, []string{"config.yaml:1: database.production is not configured."}, err.(*ConfigError).Errors)
+}
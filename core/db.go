@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	"time"
 )
 
-var cachedDB *gorm.DB
+var (
+	cachedDB      *gorm.DB
+	cachedLogMode bool
+)
 
 func GetDB() *gorm.DB {
 	if cachedDB == nil {
@@ -16,29 +20,66 @@ func GetDB() *gorm.DB {
 	return cachedDB
 }
 
+// 기본 데이터베이스와 읽기 전용 복제본들의 연결을 모두 닫습니다.
+func CloseDB() {
+	SetReplicas(nil, 0)
+
+	if cachedDB != nil {
+		cachedDB.Close()
+		cachedDB = nil
+	}
+}
+
+// 설정에서 모든 SQL 을 출력하도록 지정했는지 여부를 반환합니다.
+func IsLogMode() bool {
+	return cachedLogMode
+}
+
 // 데이터베이스 인스턴스를 생성합니다.
 func SetDB(adapter string, dbUrl string, schema string, charset string, maxOpenConns int, isPlural bool, isLogMode bool) *gorm.DB {
+	cachedDB = OpenDB(adapter, dbUrl, schema, charset, maxOpenConns, isPlural, isLogMode)
+	cachedLogMode = isLogMode
+
+	return cachedDB
+}
+
+// 새로운 데이터베이스 연결을 생성합니다.
+func OpenDB(adapter string, dbUrl string, schema string, charset string, maxOpenConns int, isPlural bool, isLogMode bool) *gorm.DB {
+	var db *gorm.DB
 	var err error
 
 	if adapter == "mysql" {
-		cachedDB, err = gorm.Open(adapter, fmt.Sprintf("%v%v?charset=%v&parseTime=True&loc=Local", dbUrl, schema, charset))
-		cachedDB.LogMode(isLogMode)
-		cachedDB.SingularTable(!isPlural)
+		db, err = gorm.Open(adapter, fmt.Sprintf("%v%v?charset=%v&parseTime=True&loc=Local", dbUrl, schema, charset))
 		Check(err)
+		db.LogMode(isLogMode)
+		db.SingularTable(!isPlural)
 	} else {
 		// TODO will be support other adapters next time...
 	}
 
-	cachedDB.DB().SetMaxOpenConns(maxOpenConns)
-	cachedDB.DB().SetMaxIdleConns(maxOpenConns)
+	db.DB().SetMaxOpenConns(maxOpenConns)
+	db.DB().SetMaxIdleConns(maxOpenConns)
 
-	return cachedDB
+	return db
 }
 
+// 환경에 설정된 기본 데이터베이스와 읽기 전용 복제본들을 연결합니다.
 func SetDBByEnv(env string) *gorm.DB {
 	adapter, dbUrl, schema, charset, maxOpenConns, plural, logMode := GetSchemaInfo(env, true)
 	db := SetDB(adapter, dbUrl, schema, charset, maxOpenConns, plural, logMode)
 
+	var replicas []*gorm.DB
+	for _, replicaUrl := range GetReplicaUrls(env) {
+		replicas = append(replicas, OpenDB(adapter, replicaUrl, schema, charset, maxOpenConns, plural, logMode))
+	}
+
+	interval := DefaultHealthCheckInterval
+	if seconds := GetConfig(false).Database[env].HealthCheckInterval; seconds > 0 {
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	SetReplicas(replicas, interval)
+
 	return db
 }
 
@@ -0,0 +1,336 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// 테이블별로 이름(label)을 붙인 레코드들입니다.
+	// 값이 `@테이블.이름` 형태라면 해당 픽스쳐의 기본 키로 치환합니다. `@@`로 시작하는 값은 `@` 하나를 떼어낸 문자열입니다.
+	Fixtures struct {
+		Tables map[string]map[string]map[string]interface{} // 테이블 이름 -> 이름 -> 컬럼 -> 값
+		schema *Schema
+		ids    map[string]interface{} // 저장된 픽스쳐의 기본 키. 키는 `테이블.이름`
+	}
+
+	fixtureRow struct {
+		table *Table
+		label string
+	}
+)
+
+const FixturesDirname = "fixtures"
+
+var referenceRegex = regexp.MustCompile(`^@([a-zA-Z0-9_]+)\.([a-zA-Z0-9_\-]+)$`)
+
+// 프로젝트의 픽스쳐 디렉토리를 읽어 데이터베이스에 저장합니다. 디렉토리를 지정하지 않으면 `fixtures`를 사용합니다.
+// 테스트에서는 `SetTestDB` 이후에 호출합니다.
+func LoadFixtures(db *gorm.DB, dir string) *Fixtures {
+	if dir == "" {
+		dir = FixturesDirname
+	}
+
+	if !filepath.IsAbs(dir) {
+		dir = path.Join(GetProjectDir(), dir)
+	}
+
+	fixtures, err := ReadFixtures(GetSchema(false), dir)
+	Check(err)
+	Check(fixtures.Load(db))
+
+	return fixtures
+}
+
+// 디렉토리의 `<테이블>.yaml`, `<테이블>.yml`, `<테이블>.json` 파일들을 읽어 스키마와 대조합니다.
+func ReadFixtures(schema *Schema, dir string) (*Fixtures, error) {
+	files, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Fixtures{Tables: map[string]map[string]map[string]interface{}{}, schema: schema}
+
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+
+		if file.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+
+		b, err := ioutil.ReadFile(path.Join(dir, file.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		var rows map[string]map[string]interface{}
+
+		if ext == ".json" {
+			err = json.Unmarshal(b, &rows)
+		} else {
+			err = yaml.Unmarshal(b, &rows)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", file.Name(), err)
+		}
+
+		table := schema.GetTable(strings.TrimSuffix(file.Name(), ext))
+
+		if table == nil {
+			return nil, fmt.Errorf("%v: `%v` table does not exist.", file.Name(), strings.TrimSuffix(file.Name(), ext))
+		}
+
+		if _, exist := f.Tables[table.Name]; exist {
+			return nil, fmt.Errorf("%v: fixtures of `%v` table are defined more than once.", file.Name(), table.Name)
+		}
+
+		for label, row := range rows {
+			for name := range row {
+				if table.Columns[CamelCase(name)] == nil {
+					return nil, fmt.Errorf("%v: `%v` column does not exist in `%v` table. (%v)", file.Name(), name, table.Name, label)
+				}
+			}
+		}
+
+		f.Tables[table.Name] = rows
+	}
+
+	// 참조하는 픽스쳐가 모두 존재하는지 미리 확인합니다.
+	if _, err = f.order(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// 참조되는 픽스쳐부터 차례대로 하나의 트랜잭션에서 저장합니다.
+func (f *Fixtures) Load(db *gorm.DB) (err error) {
+	rows, err := f.order()
+
+	if err != nil {
+		return err
+	}
+
+	tx := db.Begin()
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	f.ids = map[string]interface{}{}
+
+	for _, row := range rows {
+		if err = f.insert(tx, row); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// 저장된 픽스쳐의 기본 키를 반환합니다.
+func (f *Fixtures) Id(tableName string, label string) interface{} {
+	table := f.schema.GetTable(tableName)
+
+	if table == nil {
+		return nil
+	}
+
+	return f.ids[fixtureKey(table.Name, label)]
+}
+
+// 픽스쳐가 있는 테이블의 이름들을 정렬하여 반환합니다.
+func (f *Fixtures) TableNames() []string {
+	return sortedTables(f.Tables)
+}
+
+func (f *Fixtures) insert(tx *gorm.DB, row fixtureRow) error {
+	values := map[string]interface{}{}
+	now := time.Now()
+
+	for name, value := range f.Tables[row.table.Name][row.label] {
+		resolved, err := f.resolve(value)
+
+		if err != nil {
+			return fmt.Errorf("%v.%v: %v", row.table.Name, row.label, err)
+		}
+
+		values[row.table.Columns[CamelCase(name)].Name] = resolved
+	}
+
+	for _, column := range []string{row.table.CreatedAt, row.table.UpdatedAt} {
+		if _, exist := values[column]; column != "" && !exist {
+			values[column] = now
+		}
+	}
+
+	var columns, placeholders []string
+	var args []interface{}
+
+	for _, name := range sortedColumns(values) {
+		columns = append(columns, fmt.Sprintf("`%v`", name))
+		placeholders = append(placeholders, "?")
+		args = append(args, values[name])
+	}
+
+	result, err := tx.CommonDB().Exec(
+		fmt.Sprintf("INSERT INTO `%v` (%v) VALUES (%v)", row.table.Name, strings.Join(columns, ", "), strings.Join(placeholders, ", ")),
+		args...,
+	)
+
+	if err != nil {
+		return fmt.Errorf("%v.%v: %v", row.table.Name, row.label, err)
+	}
+
+	key := fixtureKey(row.table.Name, row.label)
+
+	if primary, err := f.schema.GetPrimary(row.table.Name); err == nil && values[primary] != nil {
+		f.ids[key] = values[primary]
+	} else if id, err := result.LastInsertId(); err == nil {
+		f.ids[key] = id
+	}
+
+	return nil
+}
+
+func (f *Fixtures) resolve(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+
+	if !ok {
+		return value, nil
+	}
+
+	if strings.HasPrefix(s, "@@") {
+		return s[1:], nil
+	}
+
+	matched := referenceRegex.FindStringSubmatch(s)
+
+	if matched == nil {
+		return value, nil
+	}
+
+	id, exist := f.ids[fixtureKey(f.schema.GetTable(matched[1]).Name, matched[2])]
+
+	if !exist {
+		return nil, fmt.Errorf("`%v` does not have a primary key.", s)
+	}
+
+	return id, nil
+}
+
+// 참조되는 픽스쳐가 먼저 오도록 정렬합니다. 순환 참조가 있다면 오류를 반환합니다.
+func (f *Fixtures) order() ([]fixtureRow, error) {
+	var rows []fixtureRow
+	visited := map[string]bool{}
+	visiting := map[string]bool{}
+
+	var visit func(table *Table, label string) error
+	visit = func(table *Table, label string) error {
+		key := fixtureKey(table.Name, label)
+
+		if visited[key] {
+			return nil
+		}
+
+		if visiting[key] {
+			return fmt.Errorf("`@%v` is referenced circularly.", key)
+		}
+
+		row, exist := f.Tables[table.Name][label]
+
+		if !exist {
+			return fmt.Errorf("`@%v` fixture does not exist.", key)
+		}
+
+		visiting[key] = true
+
+		for _, name := range sortedColumns(row) {
+			s, ok := row[name].(string)
+			matched := referenceRegex.FindStringSubmatch(s)
+
+			if !ok || matched == nil {
+				continue
+			}
+
+			referenced := f.schema.GetTable(matched[1])
+
+			if referenced == nil {
+				return fmt.Errorf("`%v` of `@%v` refers to a nonexistent table.", s, key)
+			}
+
+			if err := visit(referenced, matched[2]); err != nil {
+				return err
+			}
+		}
+
+		visiting[key] = false
+		visited[key] = true
+		rows = append(rows, fixtureRow{table: table, label: label})
+
+		return nil
+	}
+
+	for _, tableName := range sortedTables(f.Tables) {
+		for _, label := range sortedLabels(f.Tables[tableName]) {
+			if err := visit(f.schema.GetTable(tableName), label); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func fixtureKey(tableName string, label string) string {
+	return fmt.Sprintf("%v.%v", tableName, label)
+}
+
+func sortedTables(m map[string]map[string]map[string]interface{}) []string {
+	var keys []string
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedLabels(m map[string]map[string]interface{}) []string {
+	var keys []string
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedColumns(m map[string]interface{}) []string {
+	var keys []string
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func fixtureSchema() *Schema {
+	return &Schema{Tables: map[string]*Table{
+		"user": &Table{Name: "user", Columns: map[string]*Column{
+			"id":   &Column{Name: "id", Type: "int", Key: "PRI"},
+			"name": &Column{Name: "name", Type: "varchar(100)"},
+		}},
+		"bookComment": &Table{Name: "book_comment", Columns: map[string]*Column{
+			"id":       &Column{Name: "id", Type: "int", Key: "PRI"},
+			"userId":   &Column{Name: "user_id", Type: "int"},
+			"parentId": &Column{Name: "parent_id", Type: "int", Null: true},
+			"body":     &Column{Name: "body", Type: "text"},
+		}},
+	}}
+}
+
+func writeFixtures(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "fixtures")
+	assert.Nil(t, err)
+
+	for name, body := range files {
+		ioutil.WriteFile(path.Join(dir, name), []byte(body), 0644)
+	}
+
+	return dir
+}
+
+func TestReadFixtures(t *testing.T) {
+	dir := writeFixtures(t, map[string]string{
+		"user.yaml": "alice:\n  name: Alice\nbob:\n  id: 7\n  name: Bob\n",
+		"book_comment.json": `{
+			"reply": {"userId": "@user.bob", "parent_id": "@book_comment.first", "body": "@@mention"},
+			"first": {"user_id": "@user.alice", "body": "hello"}
+		}`,
+		"README.md": "ignored",
+	})
+	defer os.RemoveAll(dir)
+
+	f, err := ReadFixtures(fixtureSchema(), dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"book_comment", "user"}, f.TableNames())
+
+	rows, err := f.order()
+	assert.Nil(t, err)
+
+	var labels []string
+	for _, row := range rows {
+		labels = append(labels, fixtureKey(row.table.Name, row.label))
+	}
+
+	assert.Equal(t, []string{"user.alice", "book_comment.first", "user.bob", "book_comment.reply"}, labels)
+
+	f.ids = map[string]interface{}{"user.bob": 7}
+	value, err := f.resolve("@user.bob")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, value)
+
+	value, err = f.resolve("@@mention")
+	assert.Nil(t, err)
+	assert.Equal(t, "@mention", value)
+
+	value, err = f.resolve("alice@example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "alice@example.com", value)
+}
+
+func TestReadFixtures_Errors(t *testing.T) {
+	for _, c := range []struct {
+		name, body, message string
+	}{
+		{"book.yaml", "a:\n  title: A\n", "book.yaml: `book` table does not exist."},
+		{"user.yaml", "a:\n  email: a@b.c\n", "user.yaml: `email` column does not exist in `user` table. (a)"},
+		{"book_comment.yaml", "a:\n  user_id: \"@user.carol\"\n", "`@user.carol` fixture does not exist."},
+		{"book_comment.yaml", "a:\n  user_id: \"@writer.carol\"\n", "`@writer.carol` of `@book_comment.a` refers to a nonexistent table."},
+		{"book_comment.yaml", "a:\n  parent_id: \"@book_comment.a\"\n", "`@book_comment.a` is referenced circularly."},
+	} {
+		dir := writeFixtures(t, map[string]string{c.name: c.body})
+		_, err := ReadFixtures(fixtureSchema(), dir)
+		os.RemoveAll(dir)
+
+		if assert.NotNil(t, err, c.body) {
+			assert.Equal(t, c.message, err.Error())
+		}
+	}
+}
@@ -0,0 +1,450 @@
+package core
+
+import (
+	"encoding/json"
+)
+
+const (
+	JSONSchemaFilename = "models.schema.json"
+	OpenAPIFilename    = "openapi.json"
+	jsonSchemaDialect  = "https://json-schema.org/draft/2020-12/schema"
+	openAPIVersion     = "3.1.0"
+	apiVersion         = "1.0.0"
+)
+
+type jsonSchema map[string]interface{}
+
+// 요청 프로토콜과 모델의 JSON Schema, HTTP 엔드포인트의 OpenAPI 문서를 생성합니다.
+func BuildJSONSchema(schema *Schema) {
+	b, err := json.MarshalIndent(printJSONSchema(schema), "", "  ")
+	Check(err)
+	SaveToFile("./models/"+JSONSchemaFilename, append(b, '\n'), true)
+
+	b, err = json.MarshalIndent(printOpenAPI(schema), "", "  ")
+	Check(err)
+	SaveToFile("./models/"+OpenAPIFilename, append(b, '\n'), true)
+}
+
+func printJSONSchema(schema *Schema) jsonSchema {
+	return jsonSchema{
+		"$schema": jsonSchemaDialect,
+		"$ref":    "#/$defs/Request",
+		"$defs":   jsonSchemaDefinitions(schema, "#/$defs/"),
+	}
+}
+
+func printOpenAPI(schema *Schema) jsonSchema {
+	ref := func(name string) jsonSchema {
+		return jsonSchema{"$ref": "#/components/schemas/" + name}
+	}
+
+	content := func(s jsonSchema) jsonSchema {
+		return jsonSchema{"application/json": jsonSchema{"schema": s}}
+	}
+
+	status := content(jsonSchema{
+		"type":       "object",
+		"properties": jsonSchema{"status": jsonSchema{"type": "string"}, "message": jsonSchema{"type": "string"}},
+		"required":   []string{"status"},
+	})
+
+	// 내보내기 응답은 `_format`에 따라 한 줄에 하나의 레코드를 가진 NDJSON 또는 CSV 입니다.
+	exportContent := jsonSchema{
+		"application/x-ndjson": jsonSchema{"schema": jsonSchema{"type": "string"}},
+		"text/csv":             jsonSchema{"schema": jsonSchema{"type": "string"}},
+	}
+
+	paths := jsonSchema{
+		"/": jsonSchema{
+			"post": jsonSchema{
+				"operationId": "execute",
+				"summary":     "Execute a request, or a batch of requests when the body is an array.",
+				"parameters": []jsonSchema{
+					{"name": "X-Request-Id", "in": "header", "schema": jsonSchema{"type": "string"}},
+				},
+				"requestBody": jsonSchema{
+					"required": true,
+					"content": content(jsonSchema{"oneOf": []jsonSchema{
+						ref("Request"),
+						{"type": "array", "items": ref("Request")},
+					}}),
+				},
+				"responses": jsonSchema{
+					"200": jsonSchema{
+						"description": "The data of the root node, or the results in the order of the batch.",
+						"content": content(jsonSchema{"oneOf": []jsonSchema{
+							ref("Response"),
+							{"type": "array", "items": ref("Response")},
+						}}),
+					},
+					"400": jsonSchema{"description": "The batch can not be parsed."},
+					"413": jsonSchema{"description": "The batch has more requests than `batch.maxSize`."},
+				},
+			},
+		},
+		"/export": jsonSchema{
+			"post": jsonSchema{
+				"operationId": "export",
+				"summary":     "Stream every record of a list query without paging. The format is the `_format` argument of the root node.",
+				"requestBody": jsonSchema{"required": true, "content": content(ref("Request"))},
+				"responses": jsonSchema{
+					"200": jsonSchema{
+						"description": "The records, one per line. A failure after the first record is written as the last line.",
+						"content":     exportContent,
+					},
+					"400": jsonSchema{"description": "The body is not a list query, or the format is not supported."},
+				},
+			},
+		},
+		"/healthz": jsonSchema{
+			"get": jsonSchema{
+				"operationId": "healthz",
+				"responses":   jsonSchema{"200": jsonSchema{"description": "The process is alive.", "content": status}},
+			},
+		},
+		"/readyz": jsonSchema{
+			"get": jsonSchema{
+				"operationId": "readyz",
+				"responses": jsonSchema{
+					"200": jsonSchema{"description": "The server is ready to receive requests.", "content": status},
+					"503": jsonSchema{"description": "The server is shutting down or can not reach the database.", "content": status},
+				},
+			},
+		},
+		"/metrics": jsonSchema{
+			"get": jsonSchema{
+				"operationId": "metrics",
+				"responses": jsonSchema{
+					"200": jsonSchema{
+						"description": "Metrics in the Prometheus text format.",
+						"content":     jsonSchema{"text/plain": jsonSchema{"schema": jsonSchema{"type": "string"}}},
+					},
+				},
+			},
+		},
+	}
+
+	for path, item := range restPaths(schema, ref, content, exportContent) {
+		paths[path] = item
+	}
+
+	return jsonSchema{
+		"openapi":           openAPIVersion,
+		"jsonSchemaDialect": jsonSchemaDialect,
+		"info":              jsonSchema{"title": "octopus", "version": apiVersion},
+		"paths":             paths,
+		"components":        jsonSchema{"schemas": jsonSchemaDefinitions(schema, "#/components/schemas/")},
+	}
+}
+
+// 서버가 테이블마다 제공하는 REST 엔드포인트입니다. 컬럼의 조건은 `title[like]=Go%`처럼 쿼리 스트링으로 전달합니다.
+// 목록은 `_format`을 지정하면 페이지 제한 없이 exportContent 형식으로 스트리밍됩니다.
+func restPaths(schema *Schema, ref func(string) jsonSchema, content func(jsonSchema) jsonSchema, exportContent jsonSchema) jsonSchema {
+	paths := jsonSchema{}
+	query := func(name string, s jsonSchema, description string) jsonSchema {
+		parameter := jsonSchema{"name": name, "in": "query", "schema": s}
+
+		if description != "" {
+			parameter["description"] = description
+		}
+
+		return parameter
+	}
+	errors := jsonSchema{
+		"400": jsonSchema{"description": "The parameters or the body can not be parsed."},
+		"404": jsonSchema{"description": "The model or the record does not exist.", "content": content(ref("Error"))},
+	}
+	fields := query("_fields", jsonSchema{"type": "string"}, "Comma separated fields of the response. All columns by default.")
+
+	for _, tableName := range sortedTableNames(schema) {
+		name := Classify(tableName)
+		table := schema.Tables[tableName]
+		id := jsonSchema{"name": "id", "in": "path", "required": true, "schema": jsonSchema{"type": "string"}}
+
+		parameters := []jsonSchema{
+			fields,
+			query("_limit", jsonSchema{"type": "integer", "minimum": 0}, ""),
+			query("_offset", jsonSchema{"type": "integer", "minimum": 0}, ""),
+			query("_order", jsonSchema{"type": "string"}, "Comma separated columns. A column starting with `-` is sorted in descending order."),
+			query("_consistency", jsonSchema{"type": "string", "enum": []string{"strong"}}, ""),
+			query("_format", jsonSchema{"type": "string", "enum": []string{"ndjson", "csv"}}, "Stream every matching record in the format instead of a page of JSON."),
+		}
+
+		if table.SoftDelete != "" {
+			parameters = append(parameters,
+				query("_withDeleted", jsonSchema{"type": "boolean"}, ""),
+				query("_onlyDeleted", jsonSchema{"type": "boolean"}, ""),
+			)
+		}
+
+		for _, columnName := range sortedColumnNames(table) {
+			parameters = append(parameters, query(columnName, jsonSchema{"type": "string"}, "Equal to the value. Use `"+columnName+"[operator]` for the other operators of the filter."))
+		}
+
+		response := func(status string, description string, s jsonSchema) jsonSchema {
+			responses := jsonSchema{status: jsonSchema{"description": description, "content": content(s)}}
+
+			for code, r := range errors {
+				responses[code] = r
+			}
+
+			return responses
+		}
+
+		list := response("200", "The records matching the filters.", ref(name+"List"))
+
+		for contentType, media := range exportContent {
+			list["200"].(jsonSchema)["content"].(jsonSchema)[contentType] = media
+		}
+
+		paths["/rest/"+tableName] = jsonSchema{
+			"get": jsonSchema{
+				"operationId": "list" + name,
+				"parameters":  parameters,
+				"responses":   list,
+			},
+			"post": jsonSchema{
+				"operationId": "create" + name,
+				"parameters":  []jsonSchema{fields},
+				"requestBody": jsonSchema{"required": true, "content": content(ref(name))},
+				"responses":   response("201", "The created record.", ref(name)),
+			},
+		}
+
+		paths["/rest/"+tableName+"/{id}"] = jsonSchema{
+			"get": jsonSchema{
+				"operationId": "get" + name,
+				"parameters":  []jsonSchema{id, fields},
+				"responses":   response("200", "The record.", ref(name)),
+			},
+			"patch": jsonSchema{
+				"operationId": "update" + name,
+				"parameters":  []jsonSchema{id, fields},
+				"requestBody": jsonSchema{"required": true, "content": content(ref(name))},
+				"responses":   response("200", "The updated record.", ref(name)),
+			},
+			"delete": jsonSchema{
+				"operationId": "delete" + name,
+				"parameters":  []jsonSchema{id, fields},
+				"responses":   response("200", "The deleted record.", ref(name)),
+			},
+		}
+	}
+
+	return paths
+}
+
+// 프로토콜과 테이블별 타입의 정의들입니다. prefix 는 정의를 참조하는 경로입니다.
+func jsonSchemaDefinitions(schema *Schema, prefix string) jsonSchema {
+	ref := func(name string) jsonSchema {
+		return jsonSchema{"$ref": prefix + name}
+	}
+
+	direction := jsonSchema{"type": "string", "enum": []string{"ASC", "DESC", "asc", "desc"}}
+	matchMode := jsonSchema{"type": "string", "enum": []string{"natural", "boolean"}}
+	tableNames := sortedTableNames(schema)
+
+	// 노드의 타입은 관계 노드라면 모델 이름이고 말단 노드라면 Int, String 등의 스칼라나 커스텀 타입이므로 제한하지 않고 예시로만 둡니다.
+	var types []string
+	for _, name := range tableNames {
+		types = append(types, Classify(name))
+	}
+	types = append(types, "Int", "Float", "String", "Boolean", "DateTime")
+
+	definitions := jsonSchema{
+		"Request": jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"name":      jsonSchema{"type": "string"},
+				"operation": jsonSchema{"type": "string", "enum": []string{"query", "mutation"}},
+				"userId":    jsonSchema{"type": []string{"integer", "string", "null"}},
+				"node":      ref("Node"),
+			},
+			"required": []string{"operation", "node"},
+		},
+		"Node": jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"name":        jsonSchema{"type": "string"},
+				"type":        jsonSchema{"type": "string", "examples": types},
+				"args":        jsonSchema{"type": "object"},
+				"isLeaf":      jsonSchema{"type": "boolean"},
+				"isList":      jsonSchema{"type": "boolean"},
+				"isPlainList": jsonSchema{"type": "boolean"},
+				"fields":      jsonSchema{"type": "object", "additionalProperties": ref("Node")},
+			},
+			"required": []string{"name"},
+		},
+		"Filter": jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"eq":         jsonSchema{},
+				"ne":         jsonSchema{},
+				"in":         jsonSchema{"type": "array"},
+				"notIn":      jsonSchema{"type": "array"},
+				"nil":        jsonSchema{"type": "boolean"},
+				"lt":         jsonSchema{},
+				"lte":        jsonSchema{},
+				"gt":         jsonSchema{},
+				"gte":        jsonSchema{},
+				"between":    jsonSchema{"type": "array", "minItems": 2, "maxItems": 2},
+				"like":       jsonSchema{"type": "string"},
+				"ilike":      jsonSchema{"type": "string"},
+				"startsWith": jsonSchema{"type": "string"},
+				"endsWith":   jsonSchema{"type": "string"},
+				"contains":   jsonSchema{"type": "string"},
+				"regex":      jsonSchema{"type": "string"},
+				"isEmpty":    jsonSchema{"type": "boolean"},
+				"match": jsonSchema{"oneOf": []jsonSchema{
+					{"type": "string"},
+					{"type": "object", "properties": jsonSchema{"query": jsonSchema{"type": "string"}, "mode": matchMode}, "required": []string{"query"}},
+				}},
+			},
+			"additionalProperties": false,
+		},
+		"ColumnOrder": jsonSchema{"oneOf": []jsonSchema{
+			direction,
+			{
+				"type":                 "object",
+				"properties":           jsonSchema{"to": direction, "nulls": jsonSchema{"type": "string", "enum": []string{"first", "last"}}},
+				"additionalProperties": false,
+			},
+		}},
+		"Error": jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"_error": jsonSchema{
+					"type": "object",
+					"properties": jsonSchema{
+						"_data": jsonSchema{"type": "array", "items": jsonSchema{
+							"type":       "object",
+							"properties": jsonSchema{"key": jsonSchema{"type": "string"}, "code": jsonSchema{"type": "integer"}, "message": jsonSchema{"type": "string"}},
+						}},
+						"_count": jsonSchema{"type": "integer"},
+					},
+				},
+			},
+			"required": []string{"_error"},
+		},
+	}
+
+	var responses []jsonSchema
+
+	for _, tableName := range tableNames {
+		name := Classify(tableName)
+		table := schema.Tables[tableName]
+		properties := jsonSchema{}
+		where := jsonSchema{}
+		order := jsonSchema{}
+		columnNames := sortedColumnNames(table)
+
+		for _, columnName := range columnNames {
+			properties[columnName] = jsonSchemaType(table.Columns[columnName])
+			where[columnName] = ref("Filter")
+			order[columnName] = ref("ColumnOrder")
+		}
+
+		// 다른 테이블의 조건과 정렬은 `_object`와 함께 전달하며 서버에서 JoinX 메서드로 조인됩니다.
+		for _, other := range tableNames {
+			if other == tableName {
+				continue
+			}
+
+			object := jsonSchema{"required": []string{"_object"}}
+			where[other] = jsonSchema{"allOf": []jsonSchema{ref(Classify(other) + "Where"), object}}
+			order[other] = jsonSchema{"allOf": []jsonSchema{ref(Classify(other) + "Order"), object}}
+		}
+
+		matchGroup := func(properties jsonSchema) jsonSchema {
+			properties["columns"] = jsonSchema{"type": "array", "items": jsonSchema{"type": "string", "enum": columnNames}}
+			properties["query"] = jsonSchema{"type": "string"}
+			properties["mode"] = matchMode
+
+			return jsonSchema{"type": "object", "properties": properties, "required": []string{"columns", "query"}}
+		}
+
+		where["_not"] = ref(name + "Where")
+		where["_match"] = matchGroup(jsonSchema{})
+		where["_object"] = jsonSchema{}
+		order["_match"] = matchGroup(jsonSchema{"to": direction})
+		order["_object"] = jsonSchema{}
+
+		args := jsonSchema{
+			"_where":       ref(name + "Where"),
+			"_or":          jsonSchema{"type": "array", "items": ref(name + "Where")},
+			"_and":         jsonSchema{"type": "array", "items": jsonSchema{"type": "array", "items": ref(name + "Where")}},
+			"_order":       jsonSchema{"oneOf": []jsonSchema{ref(name + "Order"), {"type": "array", "items": ref(name + "Order")}}},
+			"_limit":       jsonSchema{"type": "integer", "minimum": 0},
+			"_offset":      jsonSchema{"type": "integer", "minimum": 0},
+			"_consistency": jsonSchema{"type": "string", "enum": []string{"strong"}},
+		}
+
+		if table.SoftDelete != "" {
+			args["_withDeleted"] = jsonSchema{"type": "boolean"}
+			args["_onlyDeleted"] = jsonSchema{"type": "boolean"}
+		}
+
+		// 모델의 필드 외에도 커스텀 메서드의 필드가 있을 수 있으므로 추가 속성을 허용합니다.
+		definitions[name] = jsonSchema{"type": "object", "properties": properties}
+		definitions[name+"Where"] = jsonSchema{"type": "object", "properties": where, "additionalProperties": false}
+		definitions[name+"Order"] = jsonSchema{"type": "object", "properties": order, "additionalProperties": false}
+		definitions[name+"ListArgs"] = jsonSchema{"type": "object", "properties": args}
+		definitions[name+"List"] = jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"_data":   jsonSchema{"type": "array", "items": ref(name)},
+				"_total":  jsonSchema{"type": "integer"},
+				"_count":  jsonSchema{"type": "integer"},
+				"_limit":  jsonSchema{"type": "integer"},
+				"_offset": jsonSchema{"type": "integer"},
+			},
+		}
+
+		responses = append(responses, ref(name), ref(name+"List"), jsonSchema{"type": "array", "items": ref(name)})
+	}
+
+	definitions["Response"] = jsonSchema{
+		"description": "The data of the root node. A list node is wrapped with `_data`, a plain list is an array.",
+		"anyOf":       append([]jsonSchema{ref("Error"), {"type": "null"}}, responses...),
+	}
+
+	return definitions
+}
+
+// 모델의 필드와 같은 규칙으로 JSON Schema 타입을 정합니다.
+func jsonSchemaType(column *Column) jsonSchema {
+	var s jsonSchema
+
+	switch columnKind(column) {
+	case intKind:
+		s = jsonSchema{"type": "integer"}
+	case floatKind:
+		s = jsonSchema{"type": "number"}
+	case boolKind:
+		s = jsonSchema{"type": "boolean"}
+	case timeKind:
+		s = jsonSchema{"type": "string", "format": "date-time"}
+	default:
+		s = jsonSchema{"type": "string"}
+	}
+
+	if values := enumValues(column); len(values) > 0 {
+		var enum []interface{}
+
+		for _, value := range values {
+			enum = append(enum, value)
+		}
+
+		if column.Null {
+			enum = append(enum, nil)
+		}
+
+		s["enum"] = enum
+	}
+
+	if column.Null {
+		s["type"] = []interface{}{s["type"], "null"}
+	}
+
+	return s
+}
@@ -0,0 +1,304 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func jsonSchemaTestSchema() *Schema {
+	return &Schema{
+		Tables: map[string]*Table{
+			"book": {
+				Name:       "book",
+				SoftDelete: "deleted_at",
+				Columns: map[string]*Column{
+					"id":        {Name: "id", Type: "int(11)", Key: "PRI"},
+					"price":     {Name: "price", Type: "float", Null: true},
+					"status":    {Name: "status", Type: "enum('draft','published')", Null: true},
+					"deletedAt": {Name: "deleted_at", Type: "datetime", Null: true},
+				},
+			},
+			"author": {
+				Name:    "author",
+				Columns: map[string]*Column{"id": {Name: "id", Type: "int(11)"}},
+			},
+		},
+	}
+}
+
+// 생성된 문서를 JSON 으로 변환했다가 되돌려 클라이언트가 읽는 형태로 비교합니다.
+func decodeJSONSchema(t *testing.T, s jsonSchema) map[string]interface{} {
+	b, err := json.Marshal(s)
+	assert.Nil(t, err)
+
+	var decoded map[string]interface{}
+	assert.Nil(t, json.Unmarshal(b, &decoded))
+
+	return decoded
+}
+
+func collectRefs(value interface{}, refs *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if key == "$ref" {
+				*refs = append(*refs, child.(string))
+			}
+
+			collectRefs(child, refs)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectRefs(child, refs)
+		}
+	}
+}
+
+func TestPrintJSONSchema(t *testing.T) {
+	decoded := decodeJSONSchema(t, printJSONSchema(jsonSchemaTestSchema()))
+	definitions := decoded["$defs"].(map[string]interface{})
+
+	var refs []string
+	collectRefs(decoded, &refs)
+
+	for _, ref := range refs {
+		assert.True(t, strings.HasPrefix(ref, "#/$defs/"), ref)
+		assert.Contains(t, definitions, strings.TrimPrefix(ref, "#/$defs/"))
+	}
+
+	book := definitions["Book"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, book["id"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"number", "null"}}, book["price"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}, "enum": []interface{}{"draft", "published", nil}}, book["status"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}, "format": "date-time"}, book["deletedAt"])
+
+	where := definitions["BookWhere"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/Filter"}, where["price"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/BookWhere"}, where["_not"])
+	assert.Contains(t, where, "author")
+	assert.NotContains(t, where, "book")
+
+	assert.Contains(t, definitions["BookListArgs"].(map[string]interface{})["properties"], "_withDeleted")
+	assert.NotContains(t, definitions["AuthorListArgs"].(map[string]interface{})["properties"], "_withDeleted")
+	assert.Equal(t, map[string]interface{}{
+		"type":     "string",
+		"examples": []interface{}{"Author", "Book", "Int", "Float", "String", "Boolean", "DateTime"},
+	}, definitions["Node"].(map[string]interface{})["properties"].(map[string]interface{})["type"])
+}
+
+func TestPrintJSONSchema_Validate(t *testing.T) {
+	decoded := decodeJSONSchema(t, printJSONSchema(jsonSchemaTestSchema()))
+
+	var valid map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"name": "books",
+		"operation": "query",
+		"userId": 7,
+		"node": {
+			"name": "bookList",
+			"type": "Book",
+			"isList": true,
+			"args": {"_where": {"price": {"gte": 10}}, "_order": {"id": "DESC"}, "_limit": 10},
+			"fields": {
+				"_data": {"name": "_data", "type": "Book", "fields": {
+					"id": {"name": "id", "type": "Int", "isLeaf": true},
+					"price": {"name": "price", "type": "Float", "isLeaf": true},
+					"deletedAt": {"name": "deletedAt", "type": "DateTime", "isLeaf": true},
+					"summary": {"name": "summary", "type": "BookSummary", "isLeaf": true},
+					"author": {"name": "author", "type": "Author", "fields": {
+						"id": {"name": "id", "type": "Int", "isLeaf": true}
+					}}
+				}},
+				"_count": {"name": "_count", "type": "Int", "isLeaf": true}
+			}
+		}
+	}`), &valid))
+	assert.Empty(t, validateJSONSchema(decoded, decoded, valid, "$"))
+
+	var invalid map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"operation": "subscription", "node": {"type": 1, "fields": {"id": {}}}}`), &invalid))
+	assert.Equal(t, []string{
+		"$.node.fields.id: `name` is required.",
+		"$.node.type: 1 is not string.",
+		"$.node: `name` is required.",
+		"$.operation: subscription is not one of [query mutation].",
+	}, validateJSONSchema(decoded, decoded, invalid, "$"))
+}
+
+// 생성된 문서가 사용하는 키워드만 지원하는 작은 검증기입니다. 오류는 경로 순서로 정렬됩니다.
+func validateJSONSchema(root map[string]interface{}, schema map[string]interface{}, value interface{}, at string) (errors []string) {
+	defer func() { sort.Strings(errors) }()
+
+	if ref, ok := schema["$ref"].(string); ok {
+		definition := root["$defs"].(map[string]interface{})[strings.TrimPrefix(ref, "#/$defs/")]
+		return validateJSONSchema(root, definition.(map[string]interface{}), value, at)
+	}
+
+	if types, exist := schema["type"]; exist && !jsonSchemaTypeMatches(types, value) {
+		return []string{fmt.Sprintf("%v: %v is not %v.", at, value, types)}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		matched := false
+
+		for _, candidate := range enum {
+			matched = matched || reflect.DeepEqual(candidate, value)
+		}
+
+		if !matched {
+			return []string{fmt.Sprintf("%v: %v is not one of %v.", at, value, enum)}
+		}
+	}
+
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matched := 0
+
+		for _, candidate := range oneOf {
+			if len(validateJSONSchema(root, candidate.(map[string]interface{}), value, at)) == 0 {
+				matched++
+			}
+		}
+
+		if matched != 1 {
+			errors = append(errors, fmt.Sprintf("%v: %v matches %v schemas of oneOf.", at, value, matched))
+		}
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, candidate := range allOf {
+			errors = append(errors, validateJSONSchema(root, candidate.(map[string]interface{}), value, at)...)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+
+		required, _ := schema["required"].([]interface{})
+
+		for _, name := range required {
+			if _, exist := v[name.(string)]; !exist {
+				errors = append(errors, fmt.Sprintf("%v: `%v` is required.", at, name))
+			}
+		}
+
+		for name, child := range v {
+			if property, exist := properties[name]; exist {
+				errors = append(errors, validateJSONSchema(root, property.(map[string]interface{}), child, at+"."+name)...)
+			} else if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+				errors = append(errors, validateJSONSchema(root, additional, child, at+"."+name)...)
+			} else if schema["additionalProperties"] == false {
+				errors = append(errors, fmt.Sprintf("%v: `%v` is not allowed.", at, name))
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, child := range v {
+				errors = append(errors, validateJSONSchema(root, items, child, fmt.Sprintf("%v[%v]", at, i))...)
+			}
+		}
+
+		if min, ok := schema["minItems"].(float64); ok && float64(len(v)) < min {
+			errors = append(errors, fmt.Sprintf("%v: must have at least %v items.", at, min))
+		}
+
+		if max, ok := schema["maxItems"].(float64); ok && float64(len(v)) > max {
+			errors = append(errors, fmt.Sprintf("%v: must have at most %v items.", at, max))
+		}
+	case float64:
+		if minimum, ok := schema["minimum"].(float64); ok && v < minimum {
+			errors = append(errors, fmt.Sprintf("%v: must be at least %v.", at, minimum))
+		}
+	}
+
+	return
+}
+
+func jsonSchemaTypeMatches(types interface{}, value interface{}) bool {
+	candidates, ok := types.([]interface{})
+
+	if !ok {
+		candidates = []interface{}{types}
+	}
+
+	for _, candidate := range candidates {
+		switch v := value.(type) {
+		case nil:
+			if candidate == "null" {
+				return true
+			}
+		case bool:
+			if candidate == "boolean" {
+				return true
+			}
+		case float64:
+			if candidate == "number" || (candidate == "integer" && v == float64(int64(v))) {
+				return true
+			}
+		case string:
+			if candidate == "string" {
+				return true
+			}
+		case []interface{}:
+			if candidate == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if candidate == "object" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func TestPrintOpenAPI(t *testing.T) {
+	decoded := decodeJSONSchema(t, printOpenAPI(jsonSchemaTestSchema()))
+	definitions := decoded["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+
+	var refs []string
+	collectRefs(decoded, &refs)
+
+	for _, ref := range refs {
+		assert.True(t, strings.HasPrefix(ref, "#/components/schemas/"), ref)
+		assert.Contains(t, definitions, strings.TrimPrefix(ref, "#/components/schemas/"))
+	}
+
+	assert.Equal(t, "3.1.0", decoded["openapi"])
+	assert.Contains(t, decoded["paths"], "/")
+	assert.Contains(t, decoded["paths"], "/readyz")
+
+	paths := decoded["paths"].(map[string]interface{})
+	assert.Contains(t, paths["/rest/book"], "get")
+	assert.Contains(t, paths["/rest/book"], "post")
+	assert.Contains(t, paths["/rest/book/{id}"], "patch")
+	assert.Contains(t, paths["/rest/author/{id}"], "delete")
+
+	export := paths["/export"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "#/components/schemas/Request", export["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})["$ref"])
+	exported := export["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})
+	assert.Contains(t, exported, "application/x-ndjson")
+	assert.Contains(t, exported, "text/csv")
+
+	list := paths["/rest/book"].(map[string]interface{})["get"].(map[string]interface{})
+	var format map[string]interface{}
+
+	for _, parameter := range list["parameters"].([]interface{}) {
+		if parameter.(map[string]interface{})["name"] == "_format" {
+			format = parameter.(map[string]interface{})
+		}
+	}
+
+	assert.Equal(t, "query", format["in"])
+	assert.Equal(t, []interface{}{"ndjson", "csv"}, format["schema"].(map[string]interface{})["enum"])
+	listed := list["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})
+	assert.Contains(t, listed, "application/json")
+	assert.Contains(t, listed, "text/csv")
+	assert.NotContains(t, paths["/rest/book/{id}"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"], "text/csv")
+}
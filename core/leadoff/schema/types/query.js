@@ -82,16 +82,27 @@ function createWhereInputType(schema, originType, targetType) {
       fields.gte = { type: GraphQLInt, name: 'gte' };
       fields.lt = { type: GraphQLInt, name: 'lt' };
       fields.lte = { type: GraphQLInt, name: 'lte' };
+      fields.between = { type: new GraphQLList(GraphQLInt), name: 'between' };
       break;
     case 'Float':
       fields.gt = { type: GraphQLFloat, name: 'gt' };
       fields.gte = { type: GraphQLFloat, name: 'gte' };
       fields.lt = { type: GraphQLFloat, name: 'lt' };
       fields.lte = { type: GraphQLFloat, name: 'lte' };
+      fields.between = { type: new GraphQLList(GraphQLFloat), name: 'between' };
       break;
     case 'String':
       fields.like = { type: GraphQLString, name: 'like' };
       fields.ilike = { type: GraphQLString, name: 'ilike' };
+      fields.match = { type: GraphQLString, name: 'match' };
+      fields.startsWith = { type: GraphQLString, name: 'startsWith' };
+      fields.endsWith = { type: GraphQLString, name: 'endsWith' };
+      fields.contains = { type: GraphQLString, name: 'contains' };
+      fields.regex = { type: GraphQLString, name: 'regex' };
+      fields.isEmpty = { type: GraphQLBoolean, name: 'isEmpty' };
+      break;
+    case 'DateTime':
+      fields.between = { type: new GraphQLList(type), name: 'between' };
       break;
   }
 
@@ -188,11 +199,23 @@ function createOrderInputTypes(schema, types) {
     },
   });
 
+  const orderNulls = new GraphQLEnumType({
+    name: 'OrderNulls',
+    values: {
+      first: { value: 'first' },
+      last: { value: 'last' },
+    },
+  });
+
   const orderInputFields = {
     to: {
       name: 'to',
       type: new GraphQLNonNull(orderDirection),
     },
+    nulls: {
+      name: 'nulls',
+      type: orderNulls,
+    },
     func: {
       name: 'func',
       type: orderFunction,
@@ -207,6 +230,7 @@ function createOrderInputTypes(schema, types) {
   orderInputType._fields = orderInputFields;
   schema._typeMap['OrderInput'] = orderInputType;
   schema._typeMap['OrderFunction'] = orderFunction;
+  schema._typeMap['OrderNulls'] = orderNulls;
   schema._typeMap['OrderDirection'] = orderDirection;
 
   const orderInputTypes = _.reduce(types, (col, type) => {
@@ -259,6 +283,12 @@ function updateQueryFromSchema(schema, types) {
   schema._typeMap.DateTime = GraphQLDateTime;
   const whereInputTypes = createWhereInputTypes(schema, types);
   const orderInputTypes = createOrderInputTypes(schema, types);
+  const consistency = new GraphQLEnumType({
+    name: 'Consistency',
+    values: {
+      strong: { value: 'strong' },
+    },
+  });
 
   _.forEach(schema._queryType._fields, (query, queryName) => {
     const strippedType = stripType(query.type);
@@ -286,7 +316,7 @@ function updateQueryFromSchema(schema, types) {
       },
       {
         name: '_order',
-        type: orderInputType,
+        type: new GraphQLList(orderInputType),
       },
       {
         name: '_offset',
@@ -296,6 +326,18 @@ function updateQueryFromSchema(schema, types) {
         name: '_limit',
         type: GraphQLInt,
       },
+      {
+        name: '_withDeleted',
+        type: GraphQLBoolean,
+      },
+      {
+        name: '_onlyDeleted',
+        type: GraphQLBoolean,
+      },
+      {
+        name: '_consistency',
+        type: consistency,
+      },
     ]);
   });
 }
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"github.com/jinzhu/gorm"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type replica struct {
+	db      *gorm.DB
+	healthy int32 // 마지막 상태 검사에서 응답했다면 1
+}
+
+var (
+	cachedReplicas  []*replica
+	replicaCursor   uint64
+	replicaLocker   sync.RWMutex
+	stopHealthCheck chan struct{}
+)
+
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// 읽기 전용 복제본들을 설정하고 주기적으로 상태를 검사합니다. 기존의 복제본들은 연결을 닫습니다.
+func SetReplicas(dbs []*gorm.DB, interval time.Duration) {
+	replicaLocker.Lock()
+	defer replicaLocker.Unlock()
+
+	if stopHealthCheck != nil {
+		close(stopHealthCheck)
+		stopHealthCheck = nil
+	}
+
+	for _, r := range cachedReplicas {
+		r.db.Close()
+	}
+
+	cachedReplicas = nil
+
+	for _, db := range dbs {
+		cachedReplicas = append(cachedReplicas, &replica{db: db, healthy: 1})
+	}
+
+	if len(cachedReplicas) > 0 && interval > 0 {
+		stopHealthCheck = make(chan struct{})
+		go checkReplicas(cachedReplicas, interval, stopHealthCheck)
+	}
+}
+
+// 읽기 전용 쿼리에 사용할 데이터베이스를 반환합니다. 정상인 복제본들을 돌아가며 사용하고,
+// 복제본이 없거나 모두 응답하지 않는다면 기본 데이터베이스를 반환합니다.
+func GetReadDB() *gorm.DB {
+	replicaLocker.RLock()
+	defer replicaLocker.RUnlock()
+
+	count := uint64(len(cachedReplicas))
+	start := atomic.AddUint64(&replicaCursor, 1)
+
+	for i := uint64(0); i < count; i++ {
+		r := cachedReplicas[(start+i)%count]
+
+		if atomic.LoadInt32(&r.healthy) == 1 {
+			return r.db
+		}
+	}
+
+	return GetDB()
+}
+
+func checkReplicas(replicas []*replica, interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			for _, r := range replicas {
+				r.check()
+			}
+		}
+	}
+}
+
+func (r *replica) check() {
+	var healthy int32
+
+	if r.db.DB().Ping() == nil {
+		healthy = 1
+	}
+
+	atomic.StoreInt32(&r.healthy, healthy)
+}
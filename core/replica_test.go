@@ -0,0 +1,58 @@
+package core
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetReadDB(t *testing.T) {
+	primary, first, second := &gorm.DB{}, &gorm.DB{}, &gorm.DB{}
+	lastDB := cachedDB
+	cachedDB = primary
+	defer func() {
+		cachedDB = lastDB
+		cachedReplicas = nil
+	}()
+
+	cachedReplicas = nil
+	assert.True(t, GetReadDB() == primary)
+
+	cachedReplicas = []*replica{{db: first, healthy: 1}, {db: second, healthy: 1}}
+	a, b, c := GetReadDB(), GetReadDB(), GetReadDB()
+	assert.True(t, a != b)
+	assert.True(t, a == c)
+	assert.True(t, a != primary && b != primary)
+
+	cachedReplicas[0].healthy = 0
+	assert.True(t, GetReadDB() == second)
+	assert.True(t, GetReadDB() == second)
+
+	cachedReplicas[1].healthy = 0
+	assert.True(t, GetReadDB() == primary)
+}
+
+func TestGetReplicaUrls(t *testing.T) {
+	lastConfig := cachedConfig
+	defer func() { cachedConfig = lastConfig }()
+
+	cachedConfig = &Config{
+		Database: map[string]DatabaseConfig{
+			"production": DatabaseConfig{
+				Username: "octopus",
+				Password: "secret",
+				Port:     "3307",
+				Replicas: []ReplicaConfig{
+					{Database: "10.0.0.2"},
+					{Database: "10.0.0.3", Username: "reader", Port: "3306"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, GetReplicaUrls("production"), []string{
+		"octopus:secret@(10.0.0.2:3307)/",
+		"reader:secret@(10.0.0.3:3306)/",
+	})
+	assert.Empty(t, GetReplicaUrls("test"))
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+type (
+	// 새로운 프로젝트를 생성할 때 템플릿에 전달되는 값들입니다.
+	ProjectOptions struct {
+		Module  string // Go 모듈 경로 (예: github.com/finwhale/bookstore)
+		Adapter string // 데이터베이스 어댑터
+	}
+)
+
+// 프로젝트의 템플릿입니다. `.tmpl`로 끝나는 파일은 옵션으로 치환한 후 확장자를 떼어내어 저장합니다.
+//
+//go:embed all:leadoff
+var leadoff embed.FS
+
+const (
+	leadoffDir        = "leadoff"
+	templateExtension = ".tmpl"
+	DefaultAdapter    = "mysql"
+)
+
+var moduleRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._~\-]*(/[a-zA-Z0-9._~\-]+)*$`)
+
+// 바이너리에 포함된 템플릿으로 Go 모듈 프로젝트를 생성합니다. 이미 존재하는 파일은 덮어쓰지 않습니다.
+func Scaffold(projectDir string, options ProjectOptions) {
+	if options.Module == "" {
+		options.Module = path.Base(filepath.ToSlash(filepath.Clean(projectDir)))
+	}
+
+	if options.Adapter == "" {
+		options.Adapter = DefaultAdapter
+	}
+
+	if !moduleRegex.MatchString(options.Module) {
+		panic(fmt.Errorf("`%v` is not a valid module path.", options.Module))
+	}
+
+	if !Contains(supportedAdapters, options.Adapter) {
+		panic(fmt.Errorf("`%v` is not a supported adapter. (%v)", options.Adapter, strings.Join(supportedAdapters, ", ")))
+	}
+
+	err := fs.WalkDir(leadoff, leadoffDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(projectDir, filepath.FromSlash(strings.TrimPrefix(name, leadoffDir)))
+
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0777)
+		}
+
+		body, err := leadoff.ReadFile(name)
+
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(name, templateExtension) {
+			target = strings.TrimSuffix(target, templateExtension)
+
+			if body, err = render(name, body, options); err != nil {
+				return err
+			}
+		}
+
+		return writeNewFile(target, body)
+	})
+	Check(err)
+
+	// 빌드 전에도 프로젝트가 컴파일될 수 있도록 비어있는 모델 파일을 생성합니다.
+	Check(writeNewFile(filepath.Join(projectDir, "models", ModelFilename), []byte(printSchemaFile(&Schema{}))))
+}
+
+func render(name string, body []byte, options ProjectOptions) ([]byte, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(string(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err = t.Execute(&buffer, options); err != nil {
+		return nil, err
+	}
+
+	// 치환된 모듈 경로에 따라 import 순서가 바뀌므로 Go 파일은 다시 정렬합니다.
+	if strings.HasSuffix(strings.TrimSuffix(name, templateExtension), ".go") {
+		return format.Source(buffer.Bytes())
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeNewFile(name string, body []byte) error {
+	if _, err := os.Stat(name); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, body, 0644)
+}
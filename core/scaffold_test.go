@@ -0,0 +1,45 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestScaffold(t *testing.T) {
+	dir, err := ioutil.TempDir("", "octopus")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	project := path.Join(dir, "bookstore")
+	os.MkdirAll(project, 0777)
+	ioutil.WriteFile(path.Join(project, AuthorityFilename), []byte("default: hasRole(\"user\")\n"), 0644)
+
+	Scaffold(project, ProjectOptions{})
+
+	read := func(name string) string {
+		b, err := ioutil.ReadFile(path.Join(project, name))
+		assert.Nil(t, err)
+		return string(b)
+	}
+
+	assert.Equal(t, "module bookstore\n\ngo 1.16\n", read("go.mod"))
+	assert.Contains(t, read("main.go"), "\t\"bookstore/customs\"\n\t\"bookstore/models\"\n")
+	assert.Contains(t, read("config.yaml"), "    adapter: mysql\n")
+	assert.Contains(t, read(path.Join("models", ModelFilename)), "func SetUp(env string)")
+	assert.Equal(t, "default: hasRole(\"user\")\n", read(AuthorityFilename))
+	assert.NotEmpty(t, read(".gitignore"))
+	assert.NotEmpty(t, read("customs/models.go"))
+
+	_, err = os.Stat(path.Join(project, "main.go.tmpl"))
+	assert.True(t, os.IsNotExist(err))
+
+	Scaffold(path.Join(dir, "other"), ProjectOptions{Module: "github.com/finwhale/other"})
+	b, _ := ioutil.ReadFile(path.Join(dir, "other", "go.mod"))
+	assert.Equal(t, "module github.com/finwhale/other\n\ngo 1.16\n", string(b))
+
+	assert.Panics(t, func() { Scaffold(path.Join(dir, "invalid"), ProjectOptions{Module: "bad module"}) })
+	assert.Panics(t, func() { Scaffold(path.Join(dir, "invalid"), ProjectOptions{Adapter: "oracle"}) })
+}
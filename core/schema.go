@@ -19,8 +19,14 @@ type (
 	}
 
 	Table struct {
-		Name    string             `json:"name"`
-		Columns map[string]*Column `json:"columns"`
+		Name       string              `json:"name"`
+		Columns    map[string]*Column  `json:"columns"`
+		FullTexts  map[string][]string `json:"fullTexts,omitempty"`  // FULLTEXT 인덱스 이름별 컬럼 이름들
+		SoftDelete string              `json:"softDelete,omitempty"` // 삭제 시각을 기록하는 컬럼의 이름
+		CreatedAt  string              `json:"createdAt,omitempty"`  // 생성 시각을 기록하는 컬럼의 이름
+		UpdatedAt  string              `json:"updatedAt,omitempty"`  // 수정 시각을 기록하는 컬럼의 이름
+		Version    string              `json:"version,omitempty"`    // 낙관적 잠금에 사용하는 버전 컬럼의 이름
+		Tenant     string              `json:"tenant,omitempty"`     // 테넌트를 구분하는 컬럼의 이름
 	}
 
 	Column struct {
@@ -113,6 +119,16 @@ func (s *Schema) MustColumn(tableName string, columnName string) *Column {
 
 var cachedSchema *Schema
 
+const (
+	DefaultSoftDeleteColumn = "deleted_at"
+	DefaultCreatedAtColumn  = "created_at"
+	DefaultUpdatedAtColumn  = "updated_at"
+	DefaultVersionColumn    = "lock_version"
+
+	// 기본 버전 컬럼이 없을 때 대신 사용하는 버전 컬럼의 이름입니다.
+	FallbackVersionColumn = "version"
+)
+
 // 기존의 스키마를 가져옵니다. 없는 경우 schema.json 파일을 참조하여 신규 생성합니다.
 func GetSchema(reload bool) *Schema {
 	if !reload && cachedSchema != nil {
@@ -157,6 +173,23 @@ func GetTables(db *gorm.DB) map[string]*Table {
 		tableRows.Scan(&tableName, tableType)
 		table := &Table{Name: tableName}
 		table.Columns = GetColumns(db, table)
+		table.FullTexts = GetFullTexts(db, table)
+		table.SoftDelete = table.FindColumnName(GetConfig(false).ColumnName(tableName, func(c ColumnsConfig) string {
+			return c.SoftDelete
+		}, DefaultSoftDeleteColumn))
+		table.CreatedAt = table.FindColumnName(GetConfig(false).ColumnName(tableName, func(c ColumnsConfig) string {
+			return c.CreatedAt
+		}, DefaultCreatedAtColumn))
+		table.UpdatedAt = table.FindColumnName(GetConfig(false).ColumnName(tableName, func(c ColumnsConfig) string {
+			return c.UpdatedAt
+		}, DefaultUpdatedAtColumn))
+		table.Version = table.findVersionColumn(GetConfig(false).ColumnName(tableName, func(c ColumnsConfig) string {
+			return c.Version
+		}, DefaultVersionColumn))
+
+		if tenant := GetConfig(false).Tenant.Column; tenant != "" {
+			table.Tenant = table.FindColumnName(tenant)
+		}
 		tables[CamelCase(tableName)] = table
 	}
 
@@ -195,43 +228,46 @@ func GetColumns(db *gorm.DB, table *Table) map[string]*Column {
 	return columns
 }
 
-func GetSchemaInfo(env string, reload bool) (adapter string, dbUrl string, schema string, charset string, maxOpenConns int, plural bool, logMode bool) {
-	config, exist := GetConfig(reload).Database[env]
+// 해당 테이블의 FULLTEXT 인덱스들을 인덱스에 포함된 순서대로 불러옵니다.
+func GetFullTexts(db *gorm.DB, table *Table) map[string][]string {
+	indexRows, err := db.Raw(
+		"SELECT INDEX_NAME, COLUMN_NAME FROM information_schema.STATISTICS "+
+			"WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_TYPE = 'FULLTEXT' "+
+			"ORDER BY INDEX_NAME, SEQ_IN_INDEX",
+		table.Name,
+	).Rows()
+	defer indexRows.Close()
+	Check(err)
 
-	if !exist {
-		panic(fmt.Errorf("The schema of %v in the `config.yaml` file is not valid.", env))
+	fullTexts := map[string][]string{}
+	for indexRows.Next() {
+		var iName, cName string
+		indexRows.Scan(&iName, &cName)
+		fullTexts[iName] = append(fullTexts[iName], cName)
 	}
 
-	username := config.Username
-	password := config.Password
-	database := config.Database
-	maxOpenConns = config.MaxConnectionPool
-	port := config.Port
-
-	if username == "" {
-		username = "root"
-	}
+	return fullTexts
+}
 
-	if password == "" {
-		password = "root"
-	}
+func GetSchemaInfo(env string, reload bool) (adapter string, dbUrl string, schema string, charset string, maxOpenConns int, plural bool, logMode bool) {
+	Check(GetConfig(reload).ValidateDatabase(env))
 
-	if database == "" {
-		database = "127.0.0.1"
-	}
+	config := GetConfig(false).Database[env]
+	maxOpenConns = config.MaxConnectionPool
+	port := config.Port
 
 	if maxOpenConns == 0 {
-		maxOpenConns = 100
+		maxOpenConns = DefaultMaxConnectionPool
 	}
 
 	if port == "" {
-		port = "3306"
+		port = DefaultPort
 	}
 
 	dbUrl = fmt.Sprintf(
 		"%v:%v@(%v:%v)/",
-		username,
-		password,
+		config.Username,
+		config.Password,
 		config.Database,
 		port,
 	)
@@ -244,6 +280,32 @@ func GetSchemaInfo(env string, reload bool) (adapter string, dbUrl string, schem
 	return
 }
 
+// 읽기 전용 복제본들의 접속 주소를 반환합니다. 복제본에 없는 접속 정보는 기본 데이터베이스의 설정을 사용합니다.
+func GetReplicaUrls(env string) []string {
+	config := GetConfig(false).Database[env]
+	var dbUrls []string
+
+	for _, replica := range config.Replicas {
+		username := firstNonEmpty(replica.Username, config.Username)
+		password := firstNonEmpty(replica.Password, config.Password)
+		port := firstNonEmpty(replica.Port, config.Port, DefaultPort)
+
+		dbUrls = append(dbUrls, fmt.Sprintf("%v:%v@(%v:%v)/", username, password, replica.Database, port))
+	}
+
+	return dbUrls
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
 func (t *Table) CreateStatement() string {
 	createStatement := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (\n", t.Name)
 
@@ -276,14 +338,68 @@ func (t *Table) CreateStatement() string {
 	}
 
 	if len(primaries) > 0 {
-		createStatement += fmt.Sprintf(",\n  PRIMARY KEY (%v)\n);", strings.Join(primaries, ","))
-	} else {
-		createStatement += ");"
+		createStatement += fmt.Sprintf(",\n  PRIMARY KEY (%v)", strings.Join(primaries, ","))
+	}
+
+	names := []string{}
+	for name := range t.FullTexts {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		createStatement += fmt.Sprintf(",\n  FULLTEXT KEY `%v` (`%v`)", name, strings.Join(t.FullTexts[name], "`, `"))
+	}
+
+	createStatement += "\n);"
 
 	return createStatement
 }
 
+// 주어진 컬럼들로만 구성된 FULLTEXT 인덱스가 있는지 확인합니다. 컬럼의 순서는 무관합니다.
+func (t *Table) HasFullText(columnNames ...string) bool {
+	if len(columnNames) == 0 {
+		return false
+	}
+
+Loop:
+	for _, indexed := range t.FullTexts {
+		if len(indexed) != len(columnNames) {
+			continue
+		}
+
+		for _, name := range columnNames {
+			if !Contains(indexed, name) {
+				continue Loop
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// 테이블에 컬럼이 존재하는 경우 실제 컬럼의 이름을 반환합니다.
+func (t *Table) FindColumnName(name string) string {
+	if column, exist := t.Columns[CamelCase(name)]; exist {
+		return column.Name
+	}
+
+	return ""
+}
+
+// 버전 컬럼을 찾습니다. 기본 이름인 lock_version 컬럼이 없다면 version 컬럼을 사용합니다.
+func (t *Table) findVersionColumn(name string) string {
+	found := t.FindColumnName(name)
+
+	if found == "" && name == DefaultVersionColumn {
+		found = t.FindColumnName(FallbackVersionColumn)
+	}
+
+	return found
+}
+
 func (t *Table) TruncateStatement() string {
 	return fmt.Sprintf("TRUNCATE TABLE %v", t.Name)
 }
@@ -32,6 +32,7 @@ func (s *SchemaSuite) TestGetSchema() {
 	var schema *Schema
 
 	s.NotPanics(func() { schema = GetSchema(true) })
+	s.NotNil(schema)
 	s.Equal(cachedSchema, GetSchema(true))
 }
 
@@ -55,6 +56,49 @@ func (s *SchemaSuite) TestCreateStatement() {
 	}
 }
 
+func (s *SchemaSuite) TestHasFullText() {
+	table := Table{
+		Name: "article",
+		FullTexts: map[string][]string{
+			"title":      []string{"title"},
+			"title_body": []string{"title", "body"},
+		},
+	}
+
+	s.True(table.HasFullText("title"))
+	s.True(table.HasFullText("body", "title"))
+	s.False(table.HasFullText("body"))
+	s.False(table.HasFullText())
+}
+
+func (s *SchemaSuite) TestFindColumnName() {
+	table := Table{
+		Name: "book",
+		Columns: map[string]*Column{
+			"deletedAt": &Column{Name: "deleted_at"},
+		},
+	}
+
+	s.Equal(table.FindColumnName("deleted_at"), "deleted_at")
+	s.Equal(table.FindColumnName("deletedAt"), "deleted_at")
+	s.Empty(table.FindColumnName("removed_at"))
+}
+
+func (s *SchemaSuite) TestFindVersionColumn() {
+	table := Table{
+		Name: "book",
+		Columns: map[string]*Column{
+			"version": &Column{Name: "version"},
+		},
+	}
+
+	s.Equal("version", table.findVersionColumn(DefaultVersionColumn))
+	s.Empty(table.findVersionColumn("revision"))
+
+	table.Columns["lockVersion"] = &Column{Name: "lock_version"}
+	s.Equal("lock_version", table.findVersionColumn(DefaultVersionColumn))
+}
+
 func TestSchemaSuite(t *testing.T) {
 	suite.Run(t, new(SchemaSuite))
 }
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const TypeScriptFilename = "models.d.ts"
+
+// 모든 테이블이 공유하는 조건과 정렬 타입입니다. request 패키지의 parseQuery, parseOrder 가 허용하는 형태와 같습니다.
+const typeScriptPrelude = `// Code generated by octopus --build --typescript. DO NOT EDIT.
+
+export type MatchMode = "natural" | "boolean";
+
+export type Match = string | { query: string; mode?: MatchMode };
+
+export interface MatchGroup<C extends string> {
+  columns: C[];
+  query: string;
+  mode?: MatchMode;
+}
+
+export interface MatchOrder<C extends string> extends MatchGroup<C> {
+  to?: Direction;
+}
+
+export interface Filter<T> {
+  eq?: T;
+  ne?: T;
+  in?: T[];
+  notIn?: T[];
+  nil?: boolean;
+  lt?: T;
+  lte?: T;
+  gt?: T;
+  gte?: T;
+  between?: [T, T];
+  like?: string;
+  ilike?: string;
+  startsWith?: string;
+  endsWith?: string;
+  contains?: string;
+  regex?: string;
+  isEmpty?: boolean;
+  match?: Match;
+}
+
+export type Direction = "ASC" | "DESC";
+
+export type ColumnOrder = Direction | { to?: Direction; nulls?: "first" | "last" };
+
+export interface ListArgs<W, O> {
+  _where?: W;
+  _or?: W[];
+  _and?: W[][];
+  _order?: O | O[];
+  _limit?: number;
+  _offset?: number;
+  _consistency?: "strong";
+}
+`
+
+var (
+	enumRegex      = regexp.MustCompile(`^enum\((.*)\)$`)
+	enumValueRegex = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
+// 스키마를 모델의 JSON 형태와 같은 TypeScript 선언 파일로 변환합니다.
+func BuildTypeScript(schema *Schema) {
+	SaveToFile("./models/"+TypeScriptFilename, []byte(printTypeScriptFile(schema)), true)
+}
+
+func printTypeScriptFile(schema *Schema) string {
+	tableNames := sortedTableNames(schema)
+	body := typeScriptPrelude
+
+	for _, name := range tableNames {
+		body += "\n" + printTypeScriptTable(schema.Tables[name], tableNames)
+	}
+
+	return body
+}
+
+func printTypeScriptTable(table *Table, tableNames []string) string {
+	name := Classify(table.Name)
+	columnNames := sortedColumnNames(table)
+
+	var model, where, order, columns string
+
+	for _, columnName := range columnNames {
+		column := table.Columns[columnName]
+		valueType := typeScriptType(column)
+
+		if column.Null {
+			model += fmt.Sprintf("  %v: %v | null;\n", columnName, valueType)
+		} else {
+			model += fmt.Sprintf("  %v: %v;\n", columnName, valueType)
+		}
+
+		where += fmt.Sprintf("  %v?: Filter<%v>;\n", columnName, valueType)
+		order += fmt.Sprintf("  %v?: ColumnOrder;\n", columnName)
+		columns += fmt.Sprintf(" | %q", columnName)
+	}
+
+	// 다른 테이블의 조건과 정렬은 `_object: true`와 함께 전달하며 서버에서 JoinX 메서드로 조인됩니다.
+	for _, tableName := range tableNames {
+		if tableName == CamelCase(table.Name) {
+			continue
+		}
+
+		where += fmt.Sprintf("  %v?: %vWhere & { _object: true };\n", tableName, Classify(tableName))
+		order += fmt.Sprintf("  %v?: %vOrder & { _object: true };\n", tableName, Classify(tableName))
+	}
+
+	if columns == "" {
+		columns = " | never"
+	}
+
+	args := fmt.Sprintf("export type %[1]vListArgs = ListArgs<%[1]vWhere, %[1]vOrder>", name)
+
+	if table.SoftDelete != "" {
+		args += " & { _withDeleted?: boolean; _onlyDeleted?: boolean }"
+	}
+
+	return fmt.Sprintf(
+		"export interface %[1]v {\n%[2]v}\n\n"+
+			"export type %[1]vColumn =%[3]v;\n\n"+
+			"export interface %[1]vWhere {\n%[4]v  _not?: %[1]vWhere;\n  _match?: MatchGroup<%[1]vColumn>;\n}\n\n"+
+			"export interface %[1]vOrder {\n%[5]v  _match?: MatchOrder<%[1]vColumn>;\n}\n\n"+
+			"%[6]v;\n",
+		name, model, strings.TrimPrefix(columns, " |"), where, order, args,
+	)
+}
+
+// 모델의 필드와 같은 규칙으로 TypeScript 타입을 정합니다. enum 컬럼은 값들의 유니온이 됩니다.
+func typeScriptType(column *Column) string {
+	if values := enumValues(column); len(values) > 0 {
+		var quoted []string
+
+		for _, value := range values {
+			quoted = append(quoted, strconv.Quote(value))
+		}
+
+		return strings.Join(quoted, " | ")
+	}
+
+	switch columnKind(column) {
+	case intKind, floatKind:
+		return "number"
+	case boolKind:
+		return "boolean"
+	}
+
+	return "string"
+}
+
+// `enum('a','b')` 형태의 컬럼 타입에서 값들을 꺼냅니다. enum 컬럼이 아니라면 nil 을 반환합니다.
+func enumValues(column *Column) (values []string) {
+	matched := enumRegex.FindStringSubmatch(column.Type)
+
+	if matched == nil {
+		return nil
+	}
+
+	for _, value := range enumValueRegex.FindAllStringSubmatch(matched[1], -1) {
+		values = append(values, strings.Replace(value[1], "''", "'", -1))
+	}
+
+	return
+}
+
+func sortedTableNames(schema *Schema) []string {
+	var names []string
+
+	for name := range schema.Tables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func sortedColumnNames(table *Table) []string {
+	var names []string
+
+	for name := range table.Columns {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPrintTypeScriptFile(t *testing.T) {
+	schema := &Schema{
+		Tables: map[string]*Table{
+			"bookReview": {
+				Name:       "book_review",
+				SoftDelete: "deleted_at",
+				Columns: map[string]*Column{
+					"id":        {Name: "id", Type: "int(11)", Key: "PRI"},
+					"score":     {Name: "score", Type: "float", Null: true},
+					"isPublic":  {Name: "is_public", Type: "tinyint(1)"},
+					"status":    {Name: "status", Type: "enum('draft','it''s done')"},
+					"body":      {Name: "body", Type: "text", Null: true},
+					"deletedAt": {Name: "deleted_at", Type: "datetime", Null: true},
+				},
+			},
+			"author": {
+				Name:    "author",
+				Columns: map[string]*Column{"id": {Name: "id", Type: "bigint(20)"}},
+			},
+		},
+	}
+
+	ts := printTypeScriptFile(schema)
+
+	assert.Contains(t, ts, "export interface BookReview {\n  body: string | null;\n  deletedAt: string | null;\n  id: number;\n  isPublic: boolean;\n  score: number | null;\n  status: \"draft\" | \"it's done\";\n}\n")
+	assert.Contains(t, ts, "export type BookReviewColumn = \"body\" | \"deletedAt\" | \"id\" | \"isPublic\" | \"score\" | \"status\";\n")
+	assert.Contains(t, ts, "  status?: Filter<\"draft\" | \"it's done\">;\n")
+	assert.Contains(t, ts, "  author?: AuthorWhere & { _object: true };\n  _not?: BookReviewWhere;\n  _match?: MatchGroup<BookReviewColumn>;\n}\n")
+	assert.Contains(t, ts, "  bookReview?: BookReviewOrder & { _object: true };\n  _match?: MatchOrder<AuthorColumn>;\n}\n")
+	assert.Contains(t, ts, "export type BookReviewListArgs = ListArgs<BookReviewWhere, BookReviewOrder> & { _withDeleted?: boolean; _onlyDeleted?: boolean };\n")
+	assert.Contains(t, ts, "export type AuthorListArgs = ListArgs<AuthorWhere, AuthorOrder>;\n")
+
+	// 모델과 같이 bigint 컬럼은 문자열로 변환됩니다.
+	assert.Contains(t, ts, "export interface Author {\n  id: string;\n}\n")
+	assert.Less(t, strings.Index(ts, "interface Author "), strings.Index(ts, "interface BookReview "))
+}
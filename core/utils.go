@@ -2,9 +2,7 @@ package core
 
 import (
 	"fmt"
-	"gopkg.in/yaml.v2"
 	"io"
-	"io/ioutil"
 	"os"
 	"path"
 	"strings"
@@ -19,25 +17,85 @@ var (
 type (
 	Config struct {
 		Env    string
+		Server struct {
+			DrainTimeout int `yaml:"drainTimeout"` // 종료할 때 진행 중인 요청을 기다리는 시간(초)
+			DrainDelay   int `yaml:"drainDelay"`   // 종료 신호를 받은 후 /readyz 가 실패하는 상태로 새로운 요청을 계속 받는 시간(초)
+		}
+		Rest struct {
+			Secret     string // REST 요청의 `Authorization: JWT <token>`을 검증하는 HS256 비밀키
+			Claim      string // JWT 에서 사용자 ID 를 가진 클레임 (기본값: user_id)
+			UserHeader string `yaml:"userHeader"` // 신뢰할 수 있는 프록시가 검증한 사용자 ID 를 전달하는 헤더
+		}
 		Paging struct {
 			Limit    int
 			MaxLimit int `yaml:"maxLimit"`
 			Offset   int
 		}
+		Batch struct {
+			Concurrency int // 일괄 요청에서 동시에 실행되는 쿼리의 최대 개수
+			MaxSize     int `yaml:"maxSize"` // 일괄 요청에 포함될 수 있는 요청의 최대 개수
+		}
+		Export struct {
+			ChunkSize int `yaml:"chunkSize"` // 내보내기에서 한 번에 FulFill 하고 응답에 쓰는 레코드의 개수
+		}
+		Audit struct {
+			Table string // 감사 기록을 저장할 테이블 이름
+			File  string // 감사 기록을 JSONL 형태로 저장할 파일 경로
+		}
+		Tenant struct {
+			Column string // 테넌트를 구분하는 컬럼 이름. 지정하면 모든 쿼리와 뮤테이션이 요청의 테넌트로 제한됩니다.
+			Header string // 테넌트를 전달하는 신뢰할 수 있는 요청 헤더
+			Field  string // 사용자 모델에서 테넌트를 가진 필드 (기본값: 컬럼 이름)
+		}
+		Log struct {
+			Disabled  bool // 요청마다 남기는 구조화된 로그를 끄는지 여부
+			SlowQuery int  `yaml:"slowQuery"` // 느린 쿼리로 기록할 SQL 의 실행 시간(ms). 0 이면 기록하지 않습니다.
+			SQLVars   bool `yaml:"sqlVars"`   // 느린 쿼리에 바인딩된 인자를 기록하는지 여부. 기본적으로 인자의 수만 기록합니다.
+		}
+		Tracing struct {
+			Exporter string            // otlp 또는 file. 비어있다면 추적하지 않습니다.
+			Endpoint string            // OTLP/HTTP 수집기의 주소
+			Headers  map[string]string // OTLP 수집기로 보낼 추가 헤더
+			File     string            // file 익스포터가 스팬을 저장할 경로
+			Service  string            // service.name (기본값: octopus)
+		}
+		Columns  ColumnsConfig
+		Models   map[string]ColumnsConfig // 모델별로 재정의하는 컬럼 이름들
 		Database map[string]DatabaseConfig
+		lines    []string // 설정 파일의 내용. 오류의 줄 번호를 찾는 데 사용합니다.
+
+		databaseErrors map[string]*ConfigError // 환경별 데이터베이스 설정의 치환 오류. 해당 환경에 연결할 때 보고합니다.
+	}
+
+	// 특별한 의미를 가지는 컬럼들의 이름입니다.
+	ColumnsConfig struct {
+		SoftDelete string `yaml:"softDelete"`
+		CreatedAt  string `yaml:"createdAt"`
+		UpdatedAt  string `yaml:"updatedAt"`
+		Version    string `yaml:"version"`
 	}
 
 	DatabaseConfig struct {
-		Schema            string
-		Adapter           string
-		Charset           string
-		Username          string
-		Password          string
-		Database          string
-		Port              string
-		Plural            bool
-		MaxConnectionPool int
-		LogMode           bool `yaml:"logmode"`
+		Schema              string
+		Adapter             string
+		Charset             string
+		Username            string
+		Password            string
+		Database            string
+		Port                string
+		Plural              bool
+		MaxConnectionPool   int
+		LogMode             bool `yaml:"logmode"`
+		Replicas            []ReplicaConfig
+		HealthCheckInterval int `yaml:"healthCheckInterval"` // 복제본의 상태를 검사하는 주기(초)
+	}
+
+	// 읽기 전용 복제본의 접속 정보입니다. 비어있는 항목은 기본 데이터베이스의 설정을 따릅니다.
+	ReplicaConfig struct {
+		Username string
+		Password string
+		Database string
+		Port     string
 	}
 )
 
@@ -72,29 +130,38 @@ func GetProjectDir() string {
 	return cachedProjectDir
 }
 
+// 설정 파일을 읽지 않고 설정을 직접 지정합니다. 테스트에서 설정을 바꿀 때 사용하며, nil 이라면 다음 GetConfig 에서 다시 읽습니다.
+func SetConfig(config *Config) {
+	cachedConfig = config
+}
+
+// 설정을 불러옵니다. 설정 파일에 오류가 있다면 모든 오류를 담아 패닉을 발생시킵니다.
 func GetConfig(reload bool) *Config {
 	if reload || cachedConfig == nil {
-		file, err := ioutil.ReadFile(path.Join(GetProjectDir(), ConfigFilename))
-
-		if err != nil {
-			return &Config{
-				Database: map[string]DatabaseConfig{
-					"test": DatabaseConfig{
-						Adapter: "mysql",
-						Charset: "utf8",
-						Schema:  "spoon_test",
-					},
-				},
-			}
-		}
-
-		err = yaml.Unmarshal(file, &cachedConfig)
+		config, err := LoadConfig()
 		Check(err)
+
+		cachedConfig = config
 	}
 
 	return cachedConfig
 }
 
+// 모델에 설정된 컬럼 이름을 찾습니다. 모델별 설정, 전역 설정, 기본값 순서로 사용합니다.
+func (c *Config) ColumnName(tableName string, get func(ColumnsConfig) string, defaultName string) string {
+	for name, model := range c.Models {
+		if IsSimilar(name, tableName) && get(model) != "" {
+			return get(model)
+		}
+	}
+
+	if name := get(c.Columns); name != "" {
+		return name
+	}
+
+	return defaultName
+}
+
 func CopyFolder(srcDir string, destDir string) {
 	src, err := os.Stat(srcDir)
 	Check(err)
@@ -3,6 +3,9 @@ package core
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
 	"strings"
 	"testing"
 )
@@ -17,12 +20,54 @@ func TestGetRootDir(t *testing.T) {
 }
 
 func TestGetSchemaInfo(t *testing.T) {
-	dbUrl, adapter, schema, charset, maxOpenConns, plural, logMode := GetSchemaInfo("test", true)
-	assert.NotEmpty(t, dbUrl)
-	assert.NotEmpty(t, adapter)
-	assert.NotEmpty(t, schema)
-	assert.NotEmpty(t, charset)
-	assert.NotZero(t, maxOpenConns)
+	dir, err := ioutil.TempDir("", "octopus")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	projectDir, config := cachedProjectDir, cachedConfig
+	defer func() { cachedProjectDir, cachedConfig = projectDir, config }()
+
+	os.Setenv("OCTOPUS_TEST_PASSWORD", "secret")
+	defer os.Unsetenv("OCTOPUS_TEST_PASSWORD")
+
+	ioutil.WriteFile(path.Join(dir, ConfigFilename), []byte(`database:
+  default: &default
+    adapter: mysql
+    charset: utf8
+  test:
+    <<: *default
+    schema: spoon_test
+    database: 10.0.0.1
+    username: spoon
+    password: ${OCTOPUS_TEST_PASSWORD}
+  broken:
+    <<: *default
+`), 0644)
+	SetProjectDir(dir)
+
+	adapter, dbUrl, schema, charset, maxOpenConns, plural, logMode := GetSchemaInfo("test", true)
+	assert.Equal(t, "spoon:secret@(10.0.0.1:3306)/", dbUrl)
+	assert.Equal(t, "mysql", adapter)
+	assert.Equal(t, "spoon_test", schema)
+	assert.Equal(t, "utf8", charset)
+	assert.Equal(t, DefaultMaxConnectionPool, maxOpenConns)
 	assert.False(t, plural)
 	assert.False(t, logMode)
+
+	assert.Panics(t, func() { GetSchemaInfo("broken", false) })
+	assert.Panics(t, func() { GetSchemaInfo("production", false) })
+}
+
+func TestConfig_ColumnName(t *testing.T) {
+	config := &Config{
+		Columns: ColumnsConfig{SoftDelete: "removed_at"},
+		Models: map[string]ColumnsConfig{
+			"user_log": ColumnsConfig{SoftDelete: "archived_at"},
+		},
+	}
+	get := func(c ColumnsConfig) string { return c.SoftDelete }
+
+	assert.Equal(t, config.ColumnName("userLog", get, DefaultSoftDeleteColumn), "archived_at")
+	assert.Equal(t, config.ColumnName("book", get, DefaultSoftDeleteColumn), "removed_at")
+	assert.Equal(t, (&Config{}).ColumnName("book", get, DefaultSoftDeleteColumn), DefaultSoftDeleteColumn)
 }
@@ -0,0 +1,464 @@
+package doctor
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// 프로젝트에서 발견한 하나의 문제입니다.
+	Diagnostic struct {
+		Level   string // error 또는 warn
+		Source  string // 문제가 발견된 파일 또는 모델
+		Message string
+	}
+
+	// 프로젝트를 검사한 결과입니다.
+	Report struct {
+		Diagnostics []Diagnostic
+	}
+)
+
+const (
+	ERROR = "error"
+	WARN  = "warn"
+)
+
+var currentUserType = reflect.TypeOf((*request.CurrentUser)(nil)).Elem()
+
+// 설정, 스키마, 권한, 등록된 모델들을 서로 대조하여 요청 시점에 패닉이 될 문제들을 찾습니다.
+// 모델은 프로젝트에서 등록하므로 `models.SetUp` 이후에 호출해야 합니다.
+func Check(env string) *Report {
+	r := &Report{}
+
+	config, err := core.LoadConfig()
+	if err == nil {
+		err = config.ValidateDatabase(env)
+	}
+
+	if configError, ok := err.(*core.ConfigError); ok {
+		// 오류는 "config.yaml:줄: 메시지" 형태이므로 줄 번호까지를 출처로 사용합니다.
+		for _, message := range configError.Errors {
+			parts := strings.SplitN(message, ": ", 2)
+			r.add(ERROR, parts[0], "%v", parts[len(parts)-1])
+		}
+	} else if err != nil {
+		r.add(ERROR, core.ConfigFilename, "%v", err)
+	}
+
+	if _, err := os.Stat(path.Join(core.GetProjectDir(), core.DBFilename)); err != nil {
+		r.add(ERROR, core.DBFilename, "The file does not exist. Run `octopus --build --env=%v` first.", env)
+		return r
+	}
+
+	schema := core.GetSchema(true)
+
+	if schema.Env != "" && schema.Env != env {
+		r.add(WARN, core.DBFilename, "The file was built for the `%v` env, not `%v`.", schema.Env, env)
+	}
+
+	// 설정에 오류가 있다면 데이터베이스에 연결할 수 없으므로 최신 여부는 검사하지 않습니다.
+	if err == nil {
+		r.checkDatabase(env, schema)
+	}
+
+	if request.GetAllFunc == nil {
+		r.add(ERROR, core.ModelFilename, "No models are registered. Run the doctor from the project. (ex: go run main.go --doctor)")
+		return r
+	}
+
+	models := request.GetAll()
+
+	r.CheckModels(schema, models)
+	r.CheckMethods(schema, models)
+
+	authority, err := loadAuthority()
+
+	if err != nil {
+		r.add(ERROR, request.AuthorityFilename, "%v", err)
+	} else {
+		r.CheckAuthority(schema, models, authority)
+	}
+
+	return r
+}
+
+// 검사 결과를 출력하고 종료 코드를 반환합니다. 오류가 있다면 1 을 반환합니다.
+func Run(env string, w io.Writer) int {
+	r := Check(env)
+	r.Write(w)
+
+	if r.HasErrors() {
+		return 1
+	}
+
+	return 0
+}
+
+func (r *Report) HasErrors() bool {
+	for _, d := range r.Diagnostics {
+		if d.Level == ERROR {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) Write(w io.Writer) {
+	var errors, warnings int
+
+	for _, d := range r.Diagnostics {
+		fmt.Fprintf(w, "%-5v %v: %v\n", d.Level, d.Source, d.Message)
+
+		if d.Level == ERROR {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	if errors+warnings == 0 {
+		fmt.Fprintln(w, "No problems found.")
+		return
+	}
+
+	fmt.Fprintf(w, "%v error(s), %v warning(s)\n", errors, warnings)
+}
+
+func (r *Report) add(level string, source string, format string, args ...interface{}) {
+	r.Diagnostics = append(r.Diagnostics, Diagnostic{Level: level, Source: source, Message: fmt.Sprintf(format, args...)})
+}
+
+// ------------------------------
+// Schema
+// ------------------------------
+
+// 데이터베이스에 연결할 수 있다면 db.json 이 최신인지 확인합니다.
+func (r *Report) checkDatabase(env string, schema *core.Schema) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r.add(WARN, core.DBFilename, "Skipped comparing with the database. (%v)", recovered)
+		}
+	}()
+
+	adapter, dbUrl, schemaName, charset, _, _, _ := core.GetSchemaInfo(env, false)
+	db := core.OpenDB(adapter, dbUrl, schemaName, charset, 1, false, false)
+	defer db.Close()
+
+	r.CheckStale(schema, &core.Schema{Tables: core.GetTables(db)})
+}
+
+// db.json 과 실제 데이터베이스의 테이블, 컬럼을 비교합니다.
+func (r *Report) CheckStale(schema *core.Schema, actual *core.Schema) {
+	const hint = "Run `octopus --build` to refresh the file."
+
+	for _, name := range tableNames(actual) {
+		if schema.GetTable(name) == nil {
+			r.add(ERROR, core.DBFilename, "`%v` table exists in the database but not in the file. %v", actual.Tables[name].Name, hint)
+		}
+	}
+
+	for _, name := range tableNames(schema) {
+		table := schema.Tables[name]
+		actualTable := actual.GetTable(table.Name)
+
+		if actualTable == nil {
+			r.add(ERROR, core.DBFilename, "`%v` table does not exist in the database. %v", table.Name, hint)
+			continue
+		}
+
+		for _, columnName := range columnNames(actualTable) {
+			if table.Columns[columnName] == nil {
+				r.add(ERROR, core.DBFilename, "`%v`.`%v` column exists in the database but not in the file. %v", table.Name, actualTable.Columns[columnName].Name, hint)
+			}
+		}
+
+		for _, columnName := range columnNames(table) {
+			column := table.Columns[columnName]
+			actualColumn := actualTable.Columns[columnName]
+
+			if actualColumn == nil {
+				r.add(ERROR, core.DBFilename, "`%v`.`%v` column does not exist in the database. %v", table.Name, column.Name, hint)
+			} else if column.Type != actualColumn.Type || column.Null != actualColumn.Null || column.Key != actualColumn.Key {
+				r.add(ERROR, core.DBFilename, "`%v`.`%v` column has changed in the database. %v", table.Name, column.Name, hint)
+			}
+		}
+	}
+}
+
+// ------------------------------
+// Models
+// ------------------------------
+
+// 등록된 모델들이 db.json 의 테이블, 컬럼과 일치하는지 확인합니다.
+func (r *Report) CheckModels(schema *core.Schema, models map[string]interface{}) {
+	const hint = "Run `octopus --build` to regenerate the models."
+
+	for _, name := range tableNames(schema) {
+		table := schema.Tables[name]
+		model := models[core.Classify(table.Name)]
+
+		if model == nil {
+			r.add(ERROR, core.ModelFilename, "`%v` model for the `%v` table is not registered. %v", core.Classify(table.Name), table.Name, hint)
+			continue
+		}
+
+		fields := modelColumns(model)
+
+		for _, columnName := range columnNames(table) {
+			column := table.Columns[columnName]
+			field, exist := fields[column.Name]
+
+			if !exist {
+				r.add(ERROR, core.ModelFilename, "`%v` model does not have a field for the `%v` column. %v", core.Classify(table.Name), column.Name, hint)
+			} else if field.Type != "" && field.Type != column.Type {
+				r.add(ERROR, core.ModelFilename, "`%v.%v` is `%v` but the `%v` column is `%v`. %v", core.Classify(table.Name), field.Name, field.Type, column.Name, column.Type, hint)
+			}
+		}
+
+		for _, columnName := range sortedKeys(fields) {
+			if table.Columns[core.CamelCase(columnName)] == nil {
+				r.add(ERROR, core.ModelFilename, "`%v.%v` refers to the `%v` column which does not exist in the `%v` table. %v", core.Classify(table.Name), fields[columnName].Name, columnName, table.Name, hint)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(models) {
+		if schema.GetTable(name) == nil {
+			r.add(WARN, core.ModelFilename, "`%v` model is registered but there is no such table in %v.", name, core.DBFilename)
+		}
+	}
+}
+
+// 모델의 `ScanX` 메서드가 존재하는 컬럼만 반환하는지, 관계를 나타내는 `GetX`, `BulkX` 메서드에 대응하는 `JoinX` 메서드가 있는지 확인합니다.
+func (r *Report) CheckMethods(schema *core.Schema, models map[string]interface{}) {
+	for _, name := range sortedKeys(models) {
+		model := models[name]
+		table := schema.GetTable(name)
+
+		if model == nil || table == nil {
+			continue
+		}
+
+		value := reflect.ValueOf(model)
+		t := value.Type()
+
+		for i := 0; i < t.NumMethod(); i++ {
+			method := t.Method(i)
+
+			if strings.HasPrefix(method.Name, core.Classify(request.SCAN)) {
+				r.checkScan(name, table, method.Name, value.Method(i))
+			}
+
+			for _, prefix := range []string{core.Classify(request.GET), core.Classify(request.BULK)} {
+				relation := strings.TrimPrefix(method.Name, prefix)
+
+				if !strings.HasPrefix(method.Name, prefix) || schema.GetTable(relation) == nil {
+					continue
+				}
+
+				if _, exist := t.MethodByName(core.EncapCase(request.JOIN, relation)); !exist {
+					r.add(
+						WARN, name,
+						"`%v` has no `%v` method. Filtering or ordering by `%v` will fail.",
+						method.Name, core.EncapCase(request.JOIN, relation), core.LowerFirst(relation),
+					)
+				}
+			}
+		}
+	}
+}
+
+func (r *Report) checkScan(modelName string, table *core.Table, methodName string, method reflect.Value) {
+	if method.Type().NumIn() != 0 || method.Type().NumOut() != 1 || method.Type().Out(0) != reflect.TypeOf([]string{}) {
+		r.add(ERROR, modelName, "`%v` must have the signature `func() []string`.", methodName)
+		return
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r.add(ERROR, modelName, "`%v` panicked. (%v)", methodName, recovered)
+		}
+	}()
+
+	for _, columnName := range method.Call(nil)[0].Interface().([]string) {
+		if table.Columns[core.CamelCase(columnName)] == nil {
+			r.add(ERROR, modelName, "`%v` returns `%v` which is not a column of the `%v` table.", methodName, columnName, table.Name)
+		}
+	}
+}
+
+// ------------------------------
+// Authority
+// ------------------------------
+
+func loadAuthority() (authority *request.Authority, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	return request.GetAuthority(true), nil
+}
+
+// authority.yaml 이 존재하는 모델, 필드, 컬럼만 참조하는지 확인합니다.
+func (r *Report) CheckAuthority(schema *core.Schema, models map[string]interface{}, authority *request.Authority) {
+	validators := []request.Validator{authority.Default.Read, authority.Default.Write, authority.Default.Deleted}
+
+	for _, name := range sortedKeys(authority.Models) {
+		authorityModel := authority.Models[name]
+		table := schema.GetTable(name)
+		validators = append(validators, authorityModel.Read.Default, authorityModel.Write.Default, authorityModel.Deleted)
+
+		if table == nil {
+			r.add(ERROR, request.AuthorityFilename, "`models.%v` refers to a nonexistent model.", core.LowerFirst(name))
+			continue
+		}
+
+		for _, permission := range []struct {
+			key string
+			request.Permission
+		}{{"read", authorityModel.Read}, {"write", authorityModel.Write}} {
+			for _, field := range sortedKeys(permission.Fields) {
+				if table.Columns[field] == nil && !hasCustomField(models[name], field) {
+					r.add(
+						ERROR, request.AuthorityFilename,
+						"`models.%v.%v.fields.%v` is neither a column of the `%v` table nor a custom field.",
+						core.LowerFirst(name), permission.key, field, table.Name,
+					)
+				}
+
+				for _, validator := range permission.Fields[field] {
+					validators = append(validators, validator)
+					r.checkValidatorField(name, table, validator)
+				}
+			}
+		}
+
+		for _, validator := range []request.Validator{authorityModel.Read.Default, authorityModel.Write.Default, authorityModel.Deleted} {
+			r.checkValidatorField(name, table, validator)
+		}
+	}
+
+	// 모든 사용자가 허용되지 않는 권한이 있다면 사용자 모델이 CurrentUser 를 구현해야 합니다.
+	for _, validator := range validators {
+		if validator.IsAll() {
+			continue
+		}
+
+		userModelName := request.UserModelName
+		if userModelName == "" {
+			userModelName = request.DEFAULT_USER
+		}
+
+		if user := models[core.Classify(userModelName)]; user == nil {
+			r.add(ERROR, request.AuthorityFilename, "`%v` uses the `%v` user model which is not registered.", validator.Expression, userModelName)
+		} else if !reflect.TypeOf(user).Implements(currentUserType) {
+			r.add(ERROR, request.AuthorityFilename, "`%v` model must implement HasId, HasRole and HasProp to be used by `%v`.", core.Classify(userModelName), validator.Expression)
+		}
+
+		break
+	}
+}
+
+func (r *Report) checkValidatorField(modelName string, table *core.Table, validator request.Validator) {
+	if validator.Field != "" && table.Columns[core.CamelCase(validator.Field)] == nil {
+		r.add(
+			ERROR, request.AuthorityFilename,
+			"`%v(.%v)` of `models.%v` refers to a column which does not exist in the `%v` table.",
+			validator.Expression, validator.Field, core.LowerFirst(modelName), table.Name,
+		)
+	}
+}
+
+// ------------------------------
+// Utils
+// ------------------------------
+
+type modelField struct {
+	Name string // 구조체 필드 이름
+	Type string // gorm 태그의 컬럼 타입
+}
+
+// 모델의 gorm 태그에서 컬럼 이름별 필드를 찾습니다.
+func modelColumns(model interface{}) map[string]modelField {
+	fields := map[string]modelField{}
+	t := reflect.Indirect(reflect.ValueOf(model)).Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		f := modelField{Name: field.Name}
+		column := ""
+
+		for _, setting := range strings.Split(field.Tag.Get("gorm"), ";") {
+			if strings.HasPrefix(setting, "column:") {
+				column = strings.TrimPrefix(setting, "column:")
+			} else if strings.HasPrefix(setting, "type:") {
+				f.Type = strings.TrimPrefix(setting, "type:")
+			}
+		}
+
+		if column != "" {
+			fields[column] = f
+		}
+	}
+
+	return fields
+}
+
+func hasCustomField(model interface{}, field string) bool {
+	if model == nil {
+		return false
+	}
+
+	t := reflect.TypeOf(model)
+	_, isGet := t.MethodByName(core.EncapCase(request.GET, field))
+	_, isBulk := t.MethodByName(core.EncapCase(request.BULK, field))
+
+	return isGet || isBulk
+}
+
+func tableNames(schema *core.Schema) []string {
+	var names []string
+
+	for name := range schema.Tables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func columnNames(table *core.Table) []string {
+	var names []string
+
+	for name := range table.Columns {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,165 @@
+package doctor
+
+import (
+	"bytes"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+type (
+	User struct {
+		Id   int64  `gorm:"type:int;column:id;primary_key"`
+		Name string `gorm:"type:varchar(100);column:name"`
+	}
+
+	Book struct {
+		FulFilled map[string]interface{} `gorm:"-"`
+		Id        int64                  `gorm:"type:int;column:id;primary_key"`
+		Title     string                 `gorm:"type:text;column:title"`
+		Isbn      string                 `gorm:"type:varchar(20);column:isbn"`
+	}
+)
+
+func (u *User) HasId(id interface{}) bool             { return false }
+func (u *User) HasRole(role string) bool              { return false }
+func (u *User) HasProp(key string, value string) bool { return false }
+
+func (b *Book) ScanSummary() []string          { return []string{"title", "subtitle"} }
+func (b *Book) GetSummary() string             { return "" }
+func (b *Book) GetUser() interface{}           { return nil }
+func (b *Book) BulkUser() (interface{}, error) { return nil, nil }
+
+func testSchema() *core.Schema {
+	return &core.Schema{
+		Env: "test",
+		Tables: map[string]*core.Table{
+			"user": &core.Table{Name: "user", Columns: map[string]*core.Column{
+				"id":   &core.Column{Name: "id", Type: "int", Key: "PRI"},
+				"name": &core.Column{Name: "name", Type: "varchar(100)"},
+			}},
+			"book": &core.Table{Name: "book", Columns: map[string]*core.Column{
+				"id":     &core.Column{Name: "id", Type: "int", Key: "PRI"},
+				"title":  &core.Column{Name: "title", Type: "varchar(200)"},
+				"userId": &core.Column{Name: "user_id", Type: "int"},
+			}},
+		},
+	}
+}
+
+func messages(r *Report) []string {
+	var messages []string
+
+	for _, d := range r.Diagnostics {
+		messages = append(messages, d.Level+" "+d.Source+": "+d.Message)
+	}
+
+	return messages
+}
+
+func TestReport_CheckModels(t *testing.T) {
+	r := &Report{}
+	r.CheckModels(testSchema(), map[string]interface{}{"User": &User{}, "Book": &Book{}, "Author": &User{}})
+
+	assert.Equal(t, []string{
+		"error models.go: `Book.Title` is `text` but the `title` column is `varchar(200)`. Run `octopus --build` to regenerate the models.",
+		"error models.go: `Book` model does not have a field for the `user_id` column. Run `octopus --build` to regenerate the models.",
+		"error models.go: `Book.Isbn` refers to the `isbn` column which does not exist in the `book` table. Run `octopus --build` to regenerate the models.",
+		"warn models.go: `Author` model is registered but there is no such table in db.json.",
+	}, messages(r))
+}
+
+func TestReport_CheckMethods(t *testing.T) {
+	r := &Report{}
+	r.CheckMethods(testSchema(), map[string]interface{}{"User": &User{}, "Book": &Book{}})
+
+	assert.Equal(t, []string{
+		"warn Book: `BulkUser` has no `JoinUser` method. Filtering or ordering by `user` will fail.",
+		"warn Book: `GetUser` has no `JoinUser` method. Filtering or ordering by `user` will fail.",
+		"error Book: `ScanSummary` returns `subtitle` which is not a column of the `book` table.",
+	}, messages(r))
+}
+
+func TestReport_CheckAuthority(t *testing.T) {
+	authority := &request.Authority{
+		Models: map[string]request.AuthorityModel{
+			"Book": request.AuthorityModel{
+				Read: request.Permission{Fields: map[string][]request.Validator{
+					"title":   []request.Validator{{Expression: "hasId", Field: "ownerId"}},
+					"summary": []request.Validator{{Expression: "hasRole", Values: []string{"admin"}}},
+					"price":   []request.Validator{{}},
+				}},
+			},
+			"Magazine": request.AuthorityModel{},
+		},
+	}
+
+	r := &Report{}
+	r.CheckAuthority(testSchema(), map[string]interface{}{"User": &User{}, "Book": &Book{}}, authority)
+
+	assert.Equal(t, []string{
+		"error authority.yaml: `models.book.read.fields.price` is neither a column of the `book` table nor a custom field.",
+		"error authority.yaml: `hasId(.ownerId)` of `models.book` refers to a column which does not exist in the `book` table.",
+		"error authority.yaml: `models.magazine` refers to a nonexistent model.",
+	}, messages(r))
+
+	r = &Report{}
+	r.CheckAuthority(testSchema(), map[string]interface{}{"Book": &Book{}}, authority)
+	assert.Contains(t, messages(r), "error authority.yaml: `hasRole` uses the `User` user model which is not registered.")
+}
+
+func TestReport_CheckStale(t *testing.T) {
+	actual := testSchema()
+	actual.Tables["book"].Columns["title"] = &core.Column{Name: "title", Type: "text"}
+	actual.Tables["author"] = &core.Table{Name: "author", Columns: map[string]*core.Column{}}
+	delete(actual.Tables, "user")
+
+	r := &Report{}
+	r.CheckStale(testSchema(), actual)
+
+	assert.Equal(t, []string{
+		"error db.json: `author` table exists in the database but not in the file. Run `octopus --build` to refresh the file.",
+		"error db.json: `book`.`title` column has changed in the database. Run `octopus --build` to refresh the file.",
+		"error db.json: `user` table does not exist in the database. Run `octopus --build` to refresh the file.",
+	}, messages(r))
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "octopus")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	projectDir := core.GetProjectDir()
+	defer core.SetProjectDir(projectDir)
+	core.SetProjectDir(dir)
+
+	var output bytes.Buffer
+	assert.Equal(t, 1, Run("test", &output))
+	assert.Contains(t, output.String(), "error db.json: The file does not exist. Run `octopus --build --env=test` first.")
+
+	ioutil.WriteFile(path.Join(dir, core.DBFilename), []byte(`{"env":"test","tables":{"user":{"name":"user","columns":{
+		"id":{"name":"id","type":"int","key":"PRI"},"name":{"name":"name","type":"varchar(100)"}}}}}`), 0644)
+	ioutil.WriteFile(path.Join(dir, core.ConfigFilename), []byte("database:\n  test:\n    adapter: mysql\n"), 0644)
+	ioutil.WriteFile(path.Join(dir, request.AuthorityFilename), []byte("default: hasRole(\"user\")\n"), 0644)
+
+	getAllFunc := request.GetAllFunc
+	defer func() { request.GetAllFunc = getAllFunc }()
+	request.GetAllFunc = func() map[string]interface{} { return map[string]interface{}{"User": &User{}} }
+
+	output.Reset()
+	assert.Equal(t, 1, Run("test", &output))
+	assert.Equal(t, "error config.yaml:2: database.test.schema is required.\n"+
+		"error config.yaml:2: database.test.charset is required.\n"+
+		"error config.yaml:2: database.test.database is required.\n"+
+		"error config.yaml:2: database.test.username is required.\n"+
+		"4 error(s), 0 warning(s)\n", output.String())
+
+	ioutil.WriteFile(path.Join(dir, core.ConfigFilename), []byte(""), 0644)
+	r := Check("test")
+	assert.True(t, r.HasErrors())
+	assert.Equal(t, []string{"error config.yaml: database.test is not configured."}, messages(r))
+}
@@ -0,0 +1,98 @@
+package farmer
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/request"
+	"sync"
+)
+
+const DefaultConcurrency = 4
+
+// 일괄 요청의 각 항목을 실행하는 함수입니다. 테스트에서 데이터베이스 없이 실행 순서를 확인할 수 있도록 분리되어 있습니다.
+var execRequest = Exec
+
+// 여러 요청을 한 번에 실행하고 요청과 동일한 순서로 결과를 반환합니다.
+// 요청은 배치의 순서대로 실행되며, 뮤테이션 사이에 연속된 쿼리들만 최대 concurrency 개까지 동시에 실행됩니다.
+// 따라서 뮤테이션 뒤의 쿼리는 항상 그 뮤테이션의 결과를 읽습니다.
+// 개별 요청에서 발생한 오류는 해당 결과에만 `_error` 형태로 담기고 나머지 요청에는 영향을 주지 않습니다.
+func ExecBatch(rs []*request.Request, concurrency int) []interface{} {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]interface{}, len(rs))
+
+	for start := 0; start < len(rs); {
+		if isMutation(rs[start]) {
+			results[start] = safeExec(rs[start])
+			start++
+			continue
+		}
+
+		end := start
+
+		for end < len(rs) && !isMutation(rs[end]) {
+			end++
+		}
+
+		execQueries(rs, results, start, end, concurrency)
+		start = end
+	}
+
+	return results
+}
+
+// start 부터 end 이전까지의 쿼리들을 최대 concurrency 개까지 동시에 실행합니다.
+func execQueries(rs []*request.Request, results []interface{}, start int, end int, concurrency int) {
+	queries := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency && i < end-start; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for index := range queries {
+				results[index] = safeExec(rs[index])
+			}
+		}()
+	}
+
+	for index := start; index < end; index++ {
+		queries <- index
+	}
+
+	close(queries)
+	wg.Wait()
+}
+
+func isMutation(r *request.Request) bool {
+	return r != nil && r.Operation == "mutation"
+}
+
+// 요청을 실행하고 패닉이 발생한 경우 오류 결과로 변환합니다.
+func safeExec(r *request.Request) (result interface{}) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			name := ""
+
+			if r != nil {
+				name = r.Name
+			}
+
+			err, ok := recovered.(error)
+
+			if !ok {
+				err = fmt.Errorf("%v", recovered)
+			}
+
+			result = request.ErrorResult(name, err)
+		}
+	}()
+
+	if r == nil {
+		panic(fmt.Errorf("Request is empty."))
+	}
+
+	return execRequest(r)
+}
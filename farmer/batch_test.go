@@ -0,0 +1,77 @@
+package farmer
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/request"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecBatch(t *testing.T) {
+	rs := []*request.Request{
+		&request.Request{Name: "first", Operation: "subscription", Node: &request.Node{Name: "user", Type: "User"}},
+		nil,
+		&request.Request{Name: "third", Operation: "query"},
+		&request.Request{Name: "fourth", Operation: "subscription", Node: &request.Node{Name: "book", Type: "Book"}},
+	}
+
+	results := ExecBatch(rs, 2)
+
+	assert.Len(t, results, 4)
+	assert.Nil(t, results[0])
+	assert.Nil(t, results[3])
+
+	for _, index := range []int{1, 2} {
+		result, ok := results[index].(*request.Result)
+		assert.True(t, ok)
+
+		errorMap := result.Data.(map[string]interface{})[request.ERROR].(map[string]interface{})
+		assert.Equal(t, errorMap[request.COUNT], 1)
+	}
+}
+
+func TestExecBatch_Order(t *testing.T) {
+	defer func() { execRequest = Exec }()
+
+	var mutated int32
+
+	execRequest = func(r *request.Request) interface{} {
+		if r.Name == "broken" {
+			panic(fmt.Errorf("Broken request."))
+		}
+
+		if r.Operation == "mutation" {
+			return fmt.Sprintf("%v:%v", r.Name, atomic.AddInt32(&mutated, 1))
+		}
+
+		// 앞선 요청일수록 늦게 끝나도록 하여 결과의 순서가 실행 순서와 무관함을 확인합니다.
+		time.Sleep(time.Duration(10-len(r.Name)) * time.Millisecond)
+
+		// 쿼리는 앞선 뮤테이션들이 모두 끝난 후에, 뒤따르는 뮤테이션보다 먼저 실행되어야 합니다.
+		return fmt.Sprintf("%v:%v", r.Name, atomic.LoadInt32(&mutated))
+	}
+
+	rs := []*request.Request{
+		{Name: "a", Operation: "query"},
+		{Name: "createBook", Operation: "mutation"},
+		{Name: "ab", Operation: "query"},
+		{Name: "broken", Operation: "query"},
+		{Name: "abc", Operation: "query"},
+		{Name: "updateBook", Operation: "mutation"},
+		{Name: "abcd", Operation: "query"},
+		{Name: "abcde", Operation: "query"},
+	}
+
+	results := ExecBatch(rs, 4)
+
+	assert.Equal(t, []interface{}{"a:0", "createBook:1", "ab:1", results[3], "abc:1", "updateBook:2", "abcd:2", "abcde:2"}, results)
+
+	// 실패한 요청은 자신의 결과에만 `_error` 로 담깁니다.
+	result, ok := results[3].(*request.Result)
+	assert.True(t, ok)
+
+	errorMap := result.Data.(map[string]interface{})[request.ERROR].(map[string]interface{})
+	assert.Equal(t, errorMap[request.COUNT], 1)
+}
@@ -7,6 +7,17 @@ import (
 func Exec(r *request.Request) interface{} {
 	r.SetUp()
 
+	done := request.ObserveRequest(r)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			request.ObservePanic(r, recovered)
+			done()
+			panic(recovered)
+		}
+
+		done()
+	}()
+
 	if r.Operation == "query" {
 		return Query(r.Node)
 	}
@@ -0,0 +1,39 @@
+package farmer
+
+import (
+	"github.com/finwhale/octopus/request"
+	"io"
+)
+
+// 목록 쿼리를 NDJSON 또는 CSV 로 스트리밍합니다. 형식은 최상위 노드의 `_format` 인자로 지정하며 기본값은 NDJSON 입니다.
+func Export(r *request.Request, w io.Writer, chunkSize int) error {
+	if r.Operation != "query" || r.Node == nil {
+		return &request.RequestError{Code: 400, Message: "Only a query can be exported."}
+	}
+
+	r.SetUp()
+
+	done := request.ObserveRequest(r)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			request.ObservePanic(r, recovered)
+			done()
+			panic(recovered)
+		}
+
+		done()
+	}()
+
+	return r.Node.Export(w, ExportFormat(r), chunkSize)
+}
+
+// 요청의 내보내기 형식을 반환합니다.
+func ExportFormat(r *request.Request) string {
+	if r.Node != nil {
+		if format, ok := r.Node.Args[request.EXPORT_FORMAT].(string); ok && format != "" {
+			return format
+		}
+	}
+
+	return request.NDJSON
+}
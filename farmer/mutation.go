@@ -4,6 +4,32 @@ import (
 	"github.com/finwhale/octopus/request"
 )
 
+// 하나의 트랜잭션 안에서 뮤테이션을 실행합니다. 검증, 권한, SQL 오류가 발생하면 모든 변경사항을 롤백합니다.
 func Mutation(n *request.Node) interface{} {
-	return nil
+	r := n.Request
+	r.Begin()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			r.Rollback()
+			panic(recovered)
+		}
+	}()
+
+	result, err := n.Mutate()
+
+	if err == nil {
+		err = r.WriteAudits()
+	}
+
+	if err != nil {
+		r.Rollback()
+		return request.ErrorResult(n.Name, err)
+	}
+
+	if err = r.Commit(); err != nil {
+		return request.ErrorResult(n.Name, err)
+	}
+
+	return result
 }
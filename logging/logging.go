@@ -0,0 +1,186 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	// 요청이 끝날 때마다 남기는 로그입니다.
+	RequestLog struct {
+		Time      time.Time   `json:"time"`
+		Level     string      `json:"level"`
+		Type      string      `json:"type"`
+		RequestId string      `json:"requestId"`
+		UserId    interface{} `json:"userId"`
+		Operation string      `json:"operation"`
+		Node      string      `json:"node"`
+		Model     string      `json:"model"`
+		Duration  float64     `json:"duration"` // ms
+		SQLCount  int64       `json:"sqlCount"`
+		Panic     string      `json:"panic,omitempty"`
+	}
+
+	// 설정된 시간보다 오래 걸린 SQL 에 대한 로그입니다.
+	SlowQueryLog struct {
+		Time      time.Time     `json:"time"`
+		Level     string        `json:"level"`
+		Type      string        `json:"type"`
+		RequestId string        `json:"requestId"`
+		Path      string        `json:"path"` // SQL 을 실행한 노드의 경로
+		SQL       string        `json:"sql"`
+		VarCount  int           `json:"varCount"`       // 바인딩된 인자의 수
+		Vars      []interface{} `json:"vars,omitempty"` // 바인딩된 인자. SQLVars 를 켠 경우에만 기록합니다.
+		Duration  float64       `json:"duration"`       // ms
+	}
+
+	// 요청 로그와 느린 쿼리 로그를 JSON 한 줄씩 출력하는 관찰자입니다.
+	Logger struct {
+		Output    io.Writer
+		SlowQuery time.Duration // 0 이면 느린 쿼리를 기록하지 않습니다.
+		Quiet     bool          // 요청 로그를 남기지 않습니다.
+		SQLVars   bool          // 느린 쿼리에 바인딩된 인자를 함께 기록합니다. 비밀번호나 개인정보가 남을 수 있습니다.
+		mutex     sync.Mutex
+		panics    sync.Map // 요청별로 발생한 패닉
+	}
+)
+
+const (
+	INFO       = "info"
+	WARN       = "warn"
+	ERROR      = "error"
+	REQUEST    = "request"
+	SLOW_QUERY = "slowQuery"
+)
+
+var registerOnce sync.Once
+
+// `config.yaml`의 log 설정으로 로거를 만들어 요청 관찰자로 등록합니다. 여러 번 호출해도 한 번만 등록됩니다.
+func Register() {
+	registerOnce.Do(func() {
+		config := core.GetConfig(false).Log
+
+		request.AddObserver(&Logger{
+			Output:    os.Stdout,
+			SlowQuery: time.Duration(config.SlowQuery) * time.Millisecond,
+			Quiet:     config.Disabled,
+			SQLVars:   config.SQLVars,
+		})
+	})
+}
+
+// 새로운 요청 ID 를 생성합니다.
+func NewRequestId() string {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(b)
+}
+
+func (l *Logger) ObserveRequest(r *request.Request) func() {
+	start := time.Now()
+
+	return func() {
+		if l.Quiet {
+			return
+		}
+
+		entry := &RequestLog{
+			Time:      start,
+			Level:     INFO,
+			Type:      REQUEST,
+			RequestId: r.Id(),
+			UserId:    r.UserId,
+			Operation: r.Operation,
+			Duration:  milliseconds(time.Since(start)),
+			SQLCount:  r.SQLCount(),
+		}
+
+		if r.Node != nil {
+			entry.Node = r.Node.Name
+			entry.Model = r.Node.Type
+		}
+
+		if recovered, exist := l.panics.Load(r); exist {
+			l.panics.Delete(r)
+			entry.Level = ERROR
+			entry.Panic = fmt.Sprintf("%v", recovered)
+		}
+
+		l.write(entry)
+	}
+}
+
+func (l *Logger) ObserveNode(_ *request.Node) func() {
+	return func() {}
+}
+
+func (l *Logger) ObserveCall(_ *request.Node, _ string) func() {
+	return func() {}
+}
+
+func (l *Logger) ObserveSQL(n *request.Node, sql string, vars []interface{}, duration time.Duration) {
+	if l.SlowQuery <= 0 || duration < l.SlowQuery {
+		return
+	}
+
+	entry := &SlowQueryLog{
+		Time:     time.Now(),
+		Level:    WARN,
+		Type:     SLOW_QUERY,
+		SQL:      sql,
+		VarCount: len(vars),
+		Duration: milliseconds(duration),
+	}
+
+	if l.SQLVars {
+		entry.Vars = vars
+	}
+
+	if n != nil {
+		entry.Path = n.Path()
+
+		if n.Request != nil {
+			entry.RequestId = n.Request.Id()
+		}
+	}
+
+	l.write(entry)
+}
+
+func (l *Logger) ObserveDenial(_ *request.Node, _ string, _ int) {
+}
+
+func (l *Logger) ObservePanic(r *request.Request, recovered interface{}) {
+	if !l.Quiet {
+		l.panics.Store(r, recovered)
+	}
+}
+
+func (l *Logger) write(entry interface{}) {
+	b, err := json.Marshal(entry)
+
+	if err != nil {
+		b, _ = json.Marshal(map[string]string{"level": ERROR, "message": err.Error()})
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.Output.Write(append(b, '\n'))
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/finwhale/octopus/request"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_ObserveRequest(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	l := &Logger{Output: buffer}
+
+	header := http.Header{}
+	header.Set(request.REQUEST_ID, "abc")
+	r := &request.Request{Operation: "query", UserId: 3, Header: header, Node: &request.Node{Name: "books", Type: "Book"}}
+
+	l.ObserveRequest(r)()
+
+	var entry map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &entry))
+	assert.Equal(t, entry["level"], INFO)
+	assert.Equal(t, entry["type"], REQUEST)
+	assert.Equal(t, entry["requestId"], "abc")
+	assert.Equal(t, entry["userId"], float64(3))
+	assert.Equal(t, entry["node"], "books")
+	assert.Equal(t, entry["model"], "Book")
+	assert.NotContains(t, entry, "panic")
+
+	buffer.Reset()
+	done := l.ObserveRequest(r)
+	l.ObservePanic(r, "boom")
+	done()
+
+	entry = map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &entry))
+	assert.Equal(t, entry["level"], ERROR)
+	assert.Equal(t, entry["panic"], "boom")
+
+	buffer.Reset()
+	(&Logger{Output: buffer, Quiet: true}).ObserveRequest(r)()
+	assert.Empty(t, buffer.String())
+}
+
+func TestLogger_ObserveSQL(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	l := &Logger{Output: buffer, SlowQuery: 100 * time.Millisecond}
+
+	r := &request.Request{Node: &request.Node{Name: "books", Type: "Book", Fields: map[string]*request.Node{
+		"author": &request.Node{Name: "author", Type: "User"},
+	}}}
+	r.SetUp()
+
+	l.ObserveSQL(r.Node, "SELECT 1", nil, 10*time.Millisecond)
+	assert.Empty(t, buffer.String())
+
+	l.ObserveSQL(r.Node.Fields["author"], "SELECT * FROM `user` WHERE `id` = ?", []interface{}{1}, 150*time.Millisecond)
+	assert.Equal(t, strings.Count(buffer.String(), "\n"), 1)
+
+	var entry map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &entry))
+	assert.Equal(t, entry["type"], SLOW_QUERY)
+	assert.Equal(t, entry["path"], "books.author")
+	assert.Equal(t, entry["duration"], float64(150))
+
+	// 바인딩된 인자는 기본적으로 수만 기록합니다.
+	assert.Equal(t, entry["varCount"], float64(1))
+	assert.NotContains(t, entry, "vars")
+
+	buffer.Reset()
+	l.SQLVars = true
+	l.ObserveSQL(r.Node, "UPDATE `user` SET `password` = ?", []interface{}{"secret"}, 150*time.Millisecond)
+
+	entry = nil
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &entry))
+	assert.Equal(t, entry["vars"], []interface{}{"secret"})
+}
+
+func TestNewRequestId(t *testing.T) {
+	assert.Len(t, NewRequestId(), 32)
+	assert.NotEqual(t, NewRequestId(), NewRequestId())
+}
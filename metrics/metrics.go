@@ -0,0 +1,278 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Prometheus 텍스트 형식으로 출력할 수 있는 지표입니다.
+	Collector interface {
+		Write(w io.Writer)
+	}
+
+	// 증가하기만 하는 지표입니다.
+	Counter struct {
+		name   string
+		help   string
+		labels []string
+		mutex  sync.Mutex
+		values map[string]float64
+	}
+
+	// 관찰된 값들을 구간별로 집계하는 지표입니다.
+	Histogram struct {
+		name    string
+		help    string
+		labels  []string
+		buckets []float64
+		mutex   sync.Mutex
+		values  map[string]*histogramValue
+	}
+
+	// 출력할 때마다 함수를 호출하여 현재 값을 얻는 지표입니다.
+	GaugeFunc struct {
+		name string
+		help string
+		get  func() float64
+	}
+
+	// 지표들을 등록하고 한 번에 출력합니다.
+	Registry struct {
+		mutex      sync.Mutex
+		collectors []Collector
+	}
+
+	histogramValue struct {
+		counts []uint64
+		sum    float64
+		count  uint64
+	}
+)
+
+var (
+	DefaultRegistry = &Registry{}
+
+	// 요청 및 노드의 처리 시간(초)에 사용하는 기본 구간입니다.
+	DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+func NewCounter(name string, help string, labels ...string) *Counter {
+	return &Counter{name: name, help: help, labels: labels, values: map[string]float64{}}
+}
+
+func NewHistogram(name string, help string, buckets []float64, labels ...string) *Histogram {
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+
+	return &Histogram{name: name, help: help, labels: labels, buckets: sorted, values: map[string]*histogramValue{}}
+}
+
+func NewGaugeFunc(name string, help string, get func() float64) *GaugeFunc {
+	return &GaugeFunc{name: name, help: help, get: get}
+}
+
+// ------------------------------
+// Registry
+// ------------------------------
+
+func (r *Registry) Register(collectors ...Collector) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.collectors = append(r.collectors, collectors...)
+}
+
+// 등록된 모든 지표를 Prometheus 텍스트 형식으로 출력합니다.
+func (r *Registry) Write(w io.Writer) {
+	r.mutex.Lock()
+	collectors := append([]Collector{}, r.collectors...)
+	r.mutex.Unlock()
+
+	for _, collector := range collectors {
+		collector.Write(w)
+	}
+}
+
+// ------------------------------
+// Counter
+// ------------------------------
+
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *Counter) Add(value float64, labelValues ...string) {
+	key := labelKey(c.labels, labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values[key] += value
+}
+
+// 레이블 값에 해당하는 현재 값을 반환합니다.
+func (c *Counter) Value(labelValues ...string) float64 {
+	key := labelKey(c.labels, labelValues)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.values[key]
+}
+
+func (c *Counter) Write(w io.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%v%v %v\n", c.name, labelString(c.labels, key, ""), formatFloat(c.values[key]))
+	}
+}
+
+// ------------------------------
+// Histogram
+// ------------------------------
+
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := labelKey(h.labels, labelValues)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	v, exist := h.values[key]
+
+	if !exist {
+		v = &histogramValue{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = v
+	}
+
+	for i, bucket := range h.buckets {
+		if value <= bucket {
+			v.counts[i]++
+		}
+	}
+
+	v.sum += value
+	v.count++
+}
+
+// 레이블 값에 해당하는 관찰 횟수를 반환합니다.
+func (h *Histogram) Count(labelValues ...string) uint64 {
+	key := labelKey(h.labels, labelValues)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if v, exist := h.values[key]; exist {
+		return v.count
+	}
+
+	return 0
+}
+
+func (h *Histogram) Write(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+
+	keys := make([]string, 0, len(h.values))
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v := h.values[key]
+
+		for i, bucket := range h.buckets {
+			le := fmt.Sprintf("le=\"%v\"", formatFloat(bucket))
+			fmt.Fprintf(w, "%v_bucket%v %v\n", h.name, labelString(h.labels, key, le), v.counts[i])
+		}
+
+		fmt.Fprintf(w, "%v_bucket%v %v\n", h.name, labelString(h.labels, key, "le=\"+Inf\""), v.count)
+		fmt.Fprintf(w, "%v_sum%v %v\n", h.name, labelString(h.labels, key, ""), formatFloat(v.sum))
+		fmt.Fprintf(w, "%v_count%v %v\n", h.name, labelString(h.labels, key, ""), v.count)
+	}
+}
+
+// ------------------------------
+// Gauge
+// ------------------------------
+
+func (g *GaugeFunc) Write(w io.Writer) {
+	writeHeader(w, g.name, g.help, "gauge")
+	fmt.Fprintf(w, "%v %v\n", g.name, formatFloat(g.get()))
+}
+
+// ------------------------------
+// Utils
+// ------------------------------
+
+const labelSeparator = "\xff"
+
+// 레이블 값들을 하나의 키로 합칩니다. 레이블의 개수가 맞지 않다면 패닉을 발생시킵니다.
+func labelKey(labels []string, values []string) string {
+	if len(labels) != len(values) {
+		panic(fmt.Errorf("%v label values are required for %v, but got %v.", len(labels), labels, len(values)))
+	}
+
+	return strings.Join(values, labelSeparator)
+}
+
+func labelString(labels []string, key string, extra string) string {
+	var pairs []string
+
+	if len(labels) > 0 {
+		for i, value := range strings.Split(key, labelSeparator) {
+			pairs = append(pairs, fmt.Sprintf("%v=\"%v\"", labels[i], escapeLabel(value)))
+		}
+	}
+
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func writeHeader(w io.Writer, name string, help string, kind string) {
+	fmt.Fprintf(w, "# HELP %v %v\n", name, help)
+	fmt.Fprintf(w, "# TYPE %v %v\n", name, kind)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCounter_Write(t *testing.T) {
+	counter := NewCounter("octopus_test_total", "Test counter.", "model")
+	counter.Inc("Book")
+	counter.Add(2, "Book")
+	counter.Inc("Us\"er")
+
+	buffer := &bytes.Buffer{}
+	counter.Write(buffer)
+
+	assert.Equal(t, buffer.String(), `# HELP octopus_test_total Test counter.
+# TYPE octopus_test_total counter
+octopus_test_total{model="Book"} 3
+octopus_test_total{model="Us\"er"} 1
+`)
+	assert.Equal(t, counter.Value("Book"), float64(3))
+	assert.Panics(t, func() { counter.Inc() })
+}
+
+func TestHistogram_Write(t *testing.T) {
+	histogram := NewHistogram("octopus_test_seconds", "Test histogram.", []float64{1, 0.1}, "type")
+	histogram.Observe(0.05, "Book")
+	histogram.Observe(0.5, "Book")
+	histogram.Observe(3, "Book")
+
+	buffer := &bytes.Buffer{}
+	histogram.Write(buffer)
+
+	assert.Equal(t, buffer.String(), `# HELP octopus_test_seconds Test histogram.
+# TYPE octopus_test_seconds histogram
+octopus_test_seconds_bucket{type="Book",le="0.1"} 1
+octopus_test_seconds_bucket{type="Book",le="1"} 2
+octopus_test_seconds_bucket{type="Book",le="+Inf"} 3
+octopus_test_seconds_sum{type="Book"} 3.55
+octopus_test_seconds_count{type="Book"} 3
+`)
+	assert.Equal(t, histogram.Count("Book"), uint64(3))
+	assert.Equal(t, histogram.Count("User"), uint64(0))
+}
+
+func TestRegistry_Write(t *testing.T) {
+	registry := &Registry{}
+	registry.Register(
+		NewGaugeFunc("octopus_test_gauge", "Test gauge.", func() float64 { return 7 }),
+		NewCounter("octopus_test_total", "Test counter."),
+	)
+
+	buffer := &bytes.Buffer{}
+	registry.Write(buffer)
+
+	assert.Equal(t, buffer.String(), `# HELP octopus_test_gauge Test gauge.
+# TYPE octopus_test_gauge gauge
+octopus_test_gauge 7
+# HELP octopus_test_total Test counter.
+# TYPE octopus_test_total counter
+`)
+}
@@ -0,0 +1,179 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type observer struct{}
+
+const unknownLabel = "unknown"
+
+var (
+	Requests = NewCounter(
+		"octopus_requests_total",
+		"Number of executed requests by operation and root model type.",
+		"operation", "model",
+	)
+	RequestDuration = NewHistogram(
+		"octopus_request_duration_seconds",
+		"Latency of requests by operation and root model type.",
+		DefaultBuckets, "operation", "model",
+	)
+	RequestSQLs = NewHistogram(
+		"octopus_request_sql_queries",
+		"Number of SQL statements executed per request.",
+		[]float64{1, 2, 5, 10, 20, 50, 100, 200}, "operation", "model",
+	)
+	NodeDuration = NewHistogram(
+		"octopus_node_resolve_duration_seconds",
+		"Time spent resolving a node by its type.",
+		DefaultBuckets, "type",
+	)
+	Denials = NewCounter(
+		"octopus_authority_denials_total",
+		"Number of fields and mutations denied by the authority.",
+		"type", "key",
+	)
+	Panics = NewCounter(
+		"octopus_panics_recovered_total",
+		"Number of panics recovered while executing requests.",
+		"operation", "model",
+	)
+
+	registerOnce sync.Once
+)
+
+// 요청을 관찰하는 지표들과 데이터베이스 커넥션 풀의 지표들을 등록합니다. 여러 번 호출해도 한 번만 등록됩니다.
+func Register() {
+	registerOnce.Do(func() {
+		request.AddObserver(observer{})
+
+		DefaultRegistry.Register(Requests, RequestDuration, RequestSQLs, NodeDuration, Denials, Panics)
+		DefaultRegistry.Register(
+			NewGaugeFunc("octopus_db_max_open_connections", "Maximum number of open connections to the database.", func() float64 {
+				return float64(dbStats().MaxOpenConnections)
+			}),
+			NewGaugeFunc("octopus_db_open_connections", "Number of established connections both in use and idle.", func() float64 {
+				return float64(dbStats().OpenConnections)
+			}),
+			NewGaugeFunc("octopus_db_in_use_connections", "Number of connections currently in use.", func() float64 {
+				return float64(dbStats().InUse)
+			}),
+			NewGaugeFunc("octopus_db_idle_connections", "Number of idle connections.", func() float64 {
+				return float64(dbStats().Idle)
+			}),
+			NewGaugeFunc("octopus_db_wait_count", "Total number of connections waited for.", func() float64 {
+				return float64(dbStats().WaitCount)
+			}),
+			NewGaugeFunc("octopus_db_wait_duration_seconds", "Total time blocked waiting for a new connection.", func() float64 {
+				return dbStats().WaitDuration.Seconds()
+			}),
+		)
+	})
+}
+
+func (observer) ObserveRequest(r *request.Request) func() {
+	start := time.Now()
+
+	return func() {
+		operation, model := requestLabels(r)
+
+		Requests.Inc(operation, model)
+		RequestDuration.Observe(time.Since(start).Seconds(), operation, model)
+		RequestSQLs.Observe(float64(r.SQLCount()), operation, model)
+	}
+}
+
+func (observer) ObserveNode(n *request.Node) func() {
+	start := time.Now()
+
+	return func() {
+		NodeDuration.Observe(time.Since(start).Seconds(), modelLabel(n.Type))
+	}
+}
+
+func (observer) ObserveCall(_ *request.Node, _ string) func() {
+	return func() {}
+}
+
+func (observer) ObserveSQL(_ *request.Node, _ string, _ []interface{}, _ time.Duration) {
+}
+
+func (observer) ObserveDenial(n *request.Node, key string, _ int) {
+	Denials.Inc(modelLabel(n.Type), keyLabel(n, key))
+}
+
+func (observer) ObservePanic(r *request.Request, _ interface{}) {
+	Panics.Inc(requestLabels(r))
+}
+
+// 레이블의 값은 클라이언트가 보낸 그대로 사용하지 않고 알려진 값으로 제한하여 시계열의 개수가 늘어나지 않도록 합니다.
+func requestLabels(r *request.Request) (operation string, model string) {
+	if r == nil {
+		return
+	}
+
+	operation = unknownLabel
+
+	if r.Operation == "query" || r.Operation == request.MUTATION {
+		operation = r.Operation
+	}
+
+	if r.Node != nil {
+		model = modelLabel(r.Node.Type)
+	}
+
+	return
+}
+
+// 등록된 모델의 타입만 레이블로 사용합니다.
+func modelLabel(modelType string) string {
+	if request.Get(modelType) == nil {
+		return unknownLabel
+	}
+
+	return modelType
+}
+
+// 모델의 컬럼, 커스텀 필드, 기본 뮤테이션과 커스텀 뮤테이션의 이름만 레이블로 사용합니다.
+func keyLabel(n *request.Node, key string) string {
+	model := request.Get(n.Type)
+
+	if model == nil {
+		return unknownLabel
+	}
+
+	if core.GetSchema(false).GetColumn(n.Type, key) != nil {
+		return key
+	}
+
+	if reflect.ValueOf(model).MethodByName(core.EncapCase(request.GET, key)).IsValid() {
+		return key
+	}
+
+	for _, action := range []string{request.CREATE, request.UPDATE, request.DELETE, request.RESTORE} {
+		if key == action+n.Type {
+			return key
+		}
+	}
+
+	if request.Mutation != nil && reflect.ValueOf(request.Mutation).MethodByName(core.Classify(key)).IsValid() {
+		return key
+	}
+
+	return unknownLabel
+}
+
+// 데이터베이스가 아직 설정되지 않았다면 빈 통계를 반환합니다.
+func dbStats() (stats sql.DBStats) {
+	defer func() {
+		recover()
+	}()
+
+	return core.GetDB().DB().Stats()
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"github.com/finwhale/octopus/request"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type labelBook struct {
+	Id int64
+}
+
+func (b *labelBook) GetSummary(_ *request.Node) interface{} {
+	return nil
+}
+
+type labelMutation struct{}
+
+func (labelMutation) PublishBook(_ *request.Node) (*request.Result, error) {
+	return nil, nil
+}
+
+func TestLabels(t *testing.T) {
+	getFunc := request.GetFunc
+	defer func() { request.GetFunc, request.Mutation = getFunc, nil }()
+
+	request.GetFunc = func(name string) interface{} {
+		if name == "Book" {
+			return &labelBook{}
+		}
+		return nil
+	}
+	request.Mutation = labelMutation{}
+
+	operation, model := requestLabels(&request.Request{Operation: "query", Node: &request.Node{Type: "Book"}})
+	assert.Equal(t, "query", operation)
+	assert.Equal(t, "Book", model)
+
+	// 클라이언트가 보낸 임의의 값은 하나의 레이블로 모읍니다.
+	operation, model = requestLabels(&request.Request{Operation: "drop", Node: &request.Node{Type: "Book1234"}})
+	assert.Equal(t, unknownLabel, operation)
+	assert.Equal(t, unknownLabel, model)
+
+	n := &request.Node{Name: "bookList", Type: "Book"}
+	assert.Equal(t, "summary", keyLabel(n, "summary"))
+	assert.Equal(t, "updateBook", keyLabel(n, "updateBook"))
+	assert.Equal(t, "publishBook", keyLabel(n, "publishBook"))
+	assert.Equal(t, unknownLabel, keyLabel(n, "anything"))
+	assert.Equal(t, unknownLabel, keyLabel(&request.Node{Type: "Unknown"}, "summary"))
+}
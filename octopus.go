@@ -4,35 +4,70 @@ import (
 	"flag"
 	"fmt"
 	"github.com/finwhale/octopus/core"
-	"path"
+	"os"
+	"os/exec"
 )
 
 var (
-	project, env       string
-	isBuild, isInstall bool
+	project, module, adapter, env        string
+	isBuild, isInstall, isDoctor, isSeed bool
+	isTypeScript, isJSONSchema           bool
 )
 
 // 커맨드 라인을 통해 넘겨받은 매개변수들을 초기화
 func init() {
 	flag.StringVar(&env, "env", "local", fmt.Sprintf("Choose the env defined in the %v", core.ConfigFilename))
 	flag.StringVar(&project, "init", "", "Create a new octopus project")
+	flag.StringVar(&module, "module", "", "Module path of the new project (default: the project directory name)")
+	flag.StringVar(&adapter, "adapter", core.DefaultAdapter, "Database adapter of the new project")
 	flag.BoolVar(&isBuild, "build", false, fmt.Sprintf("Create %v, %v", core.DBFilename, core.ModelFilename))
+	flag.BoolVar(&isTypeScript, "typescript", false, fmt.Sprintf("Also create %v with --build", core.TypeScriptFilename))
+	flag.BoolVar(&isJSONSchema, "jsonschema", false, fmt.Sprintf("Also create %v, %v with --build", core.JSONSchemaFilename, core.OpenAPIFilename))
 	flag.BoolVar(&isInstall, "install", false, fmt.Sprintf("Install dependencies"))
+	flag.BoolVar(&isSeed, "seed", false, fmt.Sprintf("Load the fixtures in the %v directory", core.FixturesDirname))
+	flag.BoolVar(&isDoctor, "doctor", false, fmt.Sprintf("Check %v, %v, %v and models of the project", core.ConfigFilename, core.DBFilename, core.AuthorityFilename))
 	flag.Parse()
 }
 
 func main() {
 	if project != "" {
-		core.CopyFolder(path.Join(core.GetRootDir(), "core/leadoff"), project)
+		core.Scaffold(project, core.ProjectOptions{Module: module, Adapter: adapter})
 
 		// success message
 		fmt.Printf("BUILD SUCCESS!\n")
 		fmt.Printf("Check the database environment in the %v and run build. (ex: octopus --build --env=local)\n", core.ConfigFilename)
+		fmt.Printf("Then run `go mod tidy` in the %v directory to fetch dependencies.\n", project)
 	} else if isBuild {
 		adapter, dbUrl, schemaName, charset, _, _, _ := core.GetSchemaInfo(env, true)
-		core.Build(true, env, adapter, dbUrl, schemaName, charset)
+		schema := core.Build(true, env, adapter, dbUrl, schemaName, charset)
+
+		if isTypeScript {
+			core.BuildTypeScript(schema)
+		}
+
+		if isJSONSchema {
+			core.BuildJSONSchema(schema)
+		}
+	} else if isSeed {
+		fixtures := core.LoadFixtures(core.SetDBByEnv(env), "")
+		defer core.CloseDB()
+
+		for _, table := range fixtures.TableNames() {
+			fmt.Printf("%v: %v\n", table, len(fixtures.Tables[table]))
+		}
+
+		fmt.Printf("SEED SUCCESS!\n")
 	} else if isInstall {
 		core.Install()
+	} else if isDoctor {
+		// 모델은 프로젝트에서 등록되므로 프로젝트를 doctor 모드로 실행합니다.
+		cmd := exec.Command("go", "run", ".", "--doctor", fmt.Sprintf("--env=%v", env))
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			os.Exit(1)
+		}
 	} else {
 		flag.PrintDefaults()
 	}
@@ -0,0 +1,54 @@
+package octopustest
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// `go test ./... -octopustest.update`로 실행하면 골든 파일을 현재의 응답으로 다시 작성합니다.
+// 사용하는 패키지의 `-update` 플래그와 충돌하지 않도록 패키지 이름을 붙였으며, 이미 가진 플래그를 쓰려면 Update 에 대입합니다.
+var Update = flag.Bool("octopustest.update", false, "Rewrite the golden files with the current responses")
+
+// 골든 파일을 저장하는 디렉토리입니다.
+var GoldenDir = "testdata"
+
+const goldenExtension = ".golden.json"
+
+// 값을 정렬된 JSON 으로 변환하여 골든 파일과 비교합니다. 골든 파일이 없다면 실패합니다.
+func AssertGolden(t testing.TB, name string, actual interface{}) {
+	t.Helper()
+
+	filename := filepath.Join(GoldenDir, name+goldenExtension)
+	b, err := json.MarshalIndent(actual, "", "  ")
+
+	if err != nil {
+		t.Fatalf("Can not encode the response of `%v`: %v", name, err)
+	}
+
+	b = append(b, '\n')
+
+	if *Update {
+		if err = os.MkdirAll(filepath.Dir(filename), 0777); err == nil {
+			err = ioutil.WriteFile(filename, b, 0644)
+		}
+
+		if err != nil {
+			t.Fatalf("Can not write `%v`: %v", filename, err)
+		}
+
+		return
+	}
+
+	expected, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		t.Fatalf("Can not read `%v`. Run the test with -octopustest.update to create it. (%v)", filename, err)
+	}
+
+	assert.JSONEq(t, string(expected), string(b), "The response does not match `%v`. Run the test with -octopustest.update to rewrite it.", filename)
+}
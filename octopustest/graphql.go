@@ -0,0 +1,536 @@
+package octopustest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"github.com/jinzhu/inflection"
+	"strconv"
+	"strings"
+)
+
+type (
+	// GraphQL 문서를 읽는 최소한의 파서입니다. 하나의 최상위 필드를 가진 query 또는 mutation 만 지원합니다.
+	parser struct {
+		tokens    []token
+		pos       int
+		variables map[string]interface{}
+	}
+
+	token struct {
+		kind  int
+		value string
+	}
+)
+
+const (
+	tokenPunctuator = iota
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenEOF
+)
+
+const listSuffix = "List"
+
+// 노드 서버가 GraphQL 을 해석하여 만드는 것과 같은 요청을 GraphQL 문서로부터 만듭니다.
+// 노드의 타입은 `db.json`의 테이블에서 추론합니다. `bookList`는 `Book`의 목록이며, 복수형 필드는 `Book`의 배열입니다.
+func ParseQuery(query string, variables map[string]interface{}) (*request.Request, error) {
+	tokens, err := tokenize(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, variables: map[string]interface{}{}}
+
+	// 변수도 실제 요청과 같은 값이 되도록 JSON 으로 변환했다가 다시 읽습니다. Go 의 int 는 float64 가 됩니다.
+	if variables != nil {
+		b, err := json.Marshal(variables)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, &p.variables); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.parseDocument()
+}
+
+// 노드 서버가 Go 서버로 전달하는 JSON 형태의 요청을 읽습니다.
+func ParseJSON(b []byte) (*request.Request, error) {
+	r := &request.Request{}
+
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+
+	if r.Node == nil {
+		return nil, fmt.Errorf("The request does not have a node.")
+	}
+
+	return r, nil
+}
+
+// ------------------------------
+// Parser
+// ------------------------------
+
+func (p *parser) parseDocument() (*request.Request, error) {
+	r := &request.Request{Name: "anonymous", Operation: "query"}
+
+	if p.peek().kind == tokenName {
+		operation := p.next().value
+
+		if operation != "query" && operation != request.MUTATION {
+			return nil, fmt.Errorf("`%v` operation is not supported.", operation)
+		}
+
+		r.Operation = operation
+
+		if p.peek().kind == tokenName {
+			r.Name = p.next().value
+		}
+
+		if p.is("(") {
+			if err := p.parseVariableDefinitions(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	node, err := p.parseField(nil, r.Operation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokenEOF {
+		return nil, fmt.Errorf("The operation is not closed.")
+	}
+
+	if !p.is("}") {
+		return nil, fmt.Errorf("Only one root field is supported. (%v)", p.peek().value)
+	}
+
+	p.next()
+
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("Unexpected `%v` after the operation.", p.peek().value)
+	}
+
+	r.Node = node
+
+	return r, nil
+}
+
+func (p *parser) parseVariableDefinitions() error {
+	p.next()
+
+	for !p.is(")") {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+
+		name := p.next().value
+
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+
+		// 타입은 검사하지 않으므로 건너뜁니다.
+		for p.peek().kind != tokenEOF && !p.is("=") && !p.is("$") && !p.is(")") {
+			p.next()
+		}
+
+		if p.is("=") {
+			p.next()
+			value, err := p.parseValue()
+
+			if err != nil {
+				return err
+			}
+
+			if _, exist := p.variables[name]; !exist {
+				p.variables[name] = value
+			}
+		}
+	}
+
+	p.next()
+
+	return nil
+}
+
+func (p *parser) parseField(parent *request.Node, operation string) (*request.Node, error) {
+	t := p.next()
+
+	if t.kind != tokenName {
+		return nil, fmt.Errorf("A field name is expected but got `%v`.", t.value)
+	}
+
+	if p.is(":") {
+		return nil, fmt.Errorf("Aliases are not supported. (%v)", t.value)
+	}
+
+	node := &request.Node{Name: t.value, Args: map[string]interface{}{}, Fields: map[string]*request.Node{}}
+
+	if p.is("(") {
+		p.next()
+
+		for !p.is(")") {
+			name := p.next()
+
+			if name.kind != tokenName {
+				return nil, fmt.Errorf("An argument name is expected but got `%v`.", name.value)
+			}
+
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+
+			value, err := p.parseValue()
+
+			if err != nil {
+				return nil, err
+			}
+
+			node.Args[name.value] = value
+		}
+
+		p.next()
+	}
+
+	if !p.is("{") {
+		node.IsLeaf = true
+		node.Type = leafType(parent, node.Name)
+
+		return node, nil
+	}
+
+	p.next()
+	inferType(parent, node, operation)
+
+	for !p.is("}") {
+		if p.peek().kind == tokenEOF {
+			return nil, fmt.Errorf("`%v` is not closed.", node.Name)
+		}
+
+		// 목록의 `_data`에서 선택한 필드들은 목록 노드의 필드가 됩니다.
+		if p.peek().kind == tokenName && p.peek().value == request.DATA && node.IsList {
+			p.next()
+
+			if err := p.expect("{"); err != nil {
+				return nil, err
+			}
+
+			for !p.is("}") {
+				field, err := p.parseField(node, operation)
+
+				if err != nil {
+					return nil, err
+				}
+
+				node.Fields[field.Name] = field
+			}
+
+			p.next()
+			continue
+		}
+
+		field, err := p.parseField(node, operation)
+
+		if err != nil {
+			return nil, err
+		}
+
+		node.Fields[field.Name] = field
+	}
+
+	p.next()
+
+	return node, nil
+}
+
+func (p *parser) parseValue() (interface{}, error) {
+	t := p.next()
+
+	switch t.kind {
+	// 실제 요청은 JSON 으로 전달되므로 숫자는 모두 float64 입니다.
+	case tokenInt, tokenFloat:
+		return strconv.ParseFloat(t.value, 64)
+	case tokenString:
+		return t.value, nil
+	case tokenName:
+		switch t.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+
+		// 열거형의 값은 문자열로 전달됩니다.
+		return t.value, nil
+	}
+
+	switch t.value {
+	case "$":
+		name := p.next().value
+		value, exist := p.variables[name]
+
+		if !exist {
+			return nil, fmt.Errorf("`$%v` variable is not defined.", name)
+		}
+
+		return value, nil
+	case "[":
+		values := []interface{}{}
+
+		for !p.is("]") {
+			if p.peek().kind == tokenEOF {
+				return nil, fmt.Errorf("A list is not closed.")
+			}
+
+			value, err := p.parseValue()
+
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		p.next()
+
+		return values, nil
+	case "{":
+		values := map[string]interface{}{}
+
+		for !p.is("}") {
+			name := p.next()
+
+			if name.kind != tokenName {
+				return nil, fmt.Errorf("A field name is expected but got `%v`.", name.value)
+			}
+
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+
+			value, err := p.parseValue()
+
+			if err != nil {
+				return nil, err
+			}
+
+			values[name.value] = value
+		}
+
+		p.next()
+
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("A value is expected but got `%v`.", t.value)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) is(punctuator string) bool {
+	return p.peek().kind == tokenPunctuator && p.peek().value == punctuator
+}
+
+func (p *parser) expect(value string) error {
+	if t := p.next(); t.kind != tokenPunctuator || t.value != value {
+		return fmt.Errorf("`%v` is expected but got `%v`.", value, t.value)
+	}
+
+	return nil
+}
+
+// ------------------------------
+// Types
+// ------------------------------
+
+// 필드의 이름으로 노드의 타입을 추론합니다.
+func inferType(parent *request.Node, node *request.Node, operation string) {
+	schema := core.GetSchema(false)
+	name := node.Name
+
+	if parent == nil && operation == request.MUTATION {
+		for _, prefix := range []string{request.CREATE, request.UPDATE, request.DELETE, request.RESTORE} {
+			if strings.HasPrefix(name, prefix) && schema.GetTable(strings.TrimPrefix(name, prefix)) != nil {
+				name = strings.TrimPrefix(name, prefix)
+				break
+			}
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(name, listSuffix) && schema.GetTable(strings.TrimSuffix(name, listSuffix)) != nil:
+		node.Type = core.Classify(strings.TrimSuffix(name, listSuffix))
+		node.IsList = true
+	case schema.GetTable(name) != nil:
+		node.Type = core.Classify(name)
+	case schema.GetTable(inflection.Singular(name)) != nil:
+		node.Type = core.Classify(inflection.Singular(name))
+		node.IsPlainList = true
+	default:
+		node.Type = core.Classify(name)
+	}
+}
+
+// 컬럼의 타입으로 말단 노드의 GraphQL 타입을 추론합니다.
+func leafType(parent *request.Node, name string) string {
+	if strings.HasPrefix(name, "_") {
+		return "Int"
+	}
+
+	if parent == nil {
+		return "String"
+	}
+
+	column := core.GetSchema(false).GetColumn(parent.Type, name)
+
+	switch {
+	case column == nil:
+		return "String"
+	case strings.HasPrefix(column.Type, "tinyint(1)"):
+		return "Boolean"
+	case strings.Contains(column.Type, "int"):
+		return "Int"
+	case strings.HasPrefix(column.Type, "float"), strings.HasPrefix(column.Type, "double"), strings.HasPrefix(column.Type, "decimal"):
+		return "Float"
+	case strings.HasPrefix(column.Type, "date"), strings.HasPrefix(column.Type, "time"):
+		return "DateTime"
+	}
+
+	return "String"
+}
+
+// ------------------------------
+// Lexer
+// ------------------------------
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case strings.ContainsRune("{}()[]:$!=", c):
+			tokens = append(tokens, token{kind: tokenPunctuator, value: string(c)})
+			i++
+		case c == '.':
+			return nil, fmt.Errorf("Fragments are not supported.")
+		case c == '"':
+			value, end, err := readString(runes, i)
+
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			start, kind := i, tokenInt
+			i++
+
+			for i < len(runes) && (runes[i] >= '0' && runes[i] <= '9' || strings.ContainsRune(".eE+-", runes[i])) {
+				if strings.ContainsRune(".eE", runes[i]) {
+					kind = tokenFloat
+				}
+				i++
+			}
+
+			tokens = append(tokens, token{kind: kind, value: string(runes[start:i])})
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			start := i
+
+			for i < len(runes) && (runes[i] == '_' || (runes[i] >= 'a' && runes[i] <= 'z') || (runes[i] >= 'A' && runes[i] <= 'Z') || (runes[i] >= '0' && runes[i] <= '9')) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenName, value: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("`%v` is an unexpected character.", string(c))
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+func readString(runes []rune, start int) (string, int, error) {
+	var b strings.Builder
+
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(runes) {
+				break
+			}
+
+			i++
+			switch runes[i] {
+			case 'n':
+				b.WriteRune('\n')
+			case 't':
+				b.WriteRune('\t')
+			case 'r':
+				b.WriteRune('\r')
+			case 'u':
+				if i+4 < len(runes) {
+					code, err := strconv.ParseUint(string(runes[i+1:i+5]), 16, 32)
+
+					if err != nil {
+						return "", 0, fmt.Errorf("`\\u%v` is an invalid escape.", string(runes[i+1:i+5]))
+					}
+
+					b.WriteRune(rune(code))
+					i += 4
+				}
+			default:
+				b.WriteRune(runes[i])
+			}
+		case '\n':
+			return "", 0, fmt.Errorf("A string is not closed.")
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("A string is not closed.")
+}
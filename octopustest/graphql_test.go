@@ -0,0 +1,137 @@
+package octopustest
+
+import (
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func setUpSchema(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "octopustest")
+	assert.Nil(t, err)
+
+	ioutil.WriteFile(path.Join(dir, core.DBFilename), []byte(`{"tables":{
+		"book":{"name":"book","columns":{
+			"id":{"name":"id","type":"int(11)","key":"PRI"},
+			"title":{"name":"title","type":"varchar(100)"},
+			"price":{"name":"price","type":"float"},
+			"isPublished":{"name":"is_published","type":"tinyint(1)"}}},
+		"comment":{"name":"comment","columns":{
+			"id":{"name":"id","type":"int(11)","key":"PRI"},
+			"createdAt":{"name":"created_at","type":"datetime"}}},
+		"author":{"name":"author","columns":{"id":{"name":"id","type":"int(11)","key":"PRI"}}}}}`), 0644)
+
+	projectDir := core.GetProjectDir()
+	core.SetProjectDir(dir)
+	core.GetSchema(true)
+
+	return func() {
+		core.SetProjectDir(projectDir)
+		core.GetSchema(true)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	defer setUpSchema(t)()
+
+	r, err := ParseQuery(`
+		query Books($limit: Int = 10, $title: String) {
+			bookList(_limit: $limit, title: { like: $title }, _order: [{ price: DESC }]) {
+				_total
+				_data {
+					id
+					title
+					price
+					isPublished
+					author { id }
+					comments(_limit: 3) { createdAt }
+				}
+			}
+		}
+	`, map[string]interface{}{"title": "Go%"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Books", r.Name)
+	assert.Equal(t, "query", r.Operation)
+
+	n := r.Node
+	assert.Equal(t, "bookList", n.Name)
+	assert.Equal(t, "Book", n.Type)
+	assert.True(t, n.IsList)
+	assert.Equal(t, map[string]interface{}{
+		"_limit": float64(10),
+		"title":  map[string]interface{}{"like": "Go%"},
+		"_order": []interface{}{map[string]interface{}{"price": "DESC"}},
+	}, n.Args)
+
+	assert.Len(t, n.Fields, 7)
+	assert.Equal(t, "Int", n.Fields["_total"].Type)
+	assert.Equal(t, "Int", n.Fields["id"].Type)
+	assert.Equal(t, "String", n.Fields["title"].Type)
+	assert.Equal(t, "Float", n.Fields["price"].Type)
+	assert.Equal(t, "Boolean", n.Fields["isPublished"].Type)
+	assert.True(t, n.Fields["title"].IsLeaf)
+
+	assert.Equal(t, "Author", n.Fields["author"].Type)
+	assert.False(t, n.Fields["author"].IsPlainList)
+	assert.Equal(t, "Comment", n.Fields["comments"].Type)
+	assert.True(t, n.Fields["comments"].IsPlainList)
+	assert.Equal(t, map[string]interface{}{"_limit": float64(3)}, n.Fields["comments"].Args)
+	assert.Equal(t, "DateTime", n.Fields["comments"].Fields["createdAt"].Type)
+}
+
+func TestParseQuery_Mutation(t *testing.T) {
+	defer setUpSchema(t)()
+
+	r, err := ParseQuery(`mutation { updateBook(id: 1, title: "Go \"in\" Action", price: 12.5, lockVersion: null) { id title } }`, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "anonymous", r.Name)
+	assert.Equal(t, request.MUTATION, r.Operation)
+	assert.Equal(t, "Book", r.Node.Type)
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "title": `Go "in" Action`, "price": 12.5, "lockVersion": nil}, r.Node.Args)
+	assert.Len(t, r.Node.Fields, 2)
+
+	// Go 의 변수도 JSON 으로 전달된 것과 같이 float64 가 됩니다.
+	r, err = ParseQuery(`mutation ($id: ID!, $ids: [ID]) { updateBook(id: $id, tagIds: $ids) { id } }`, map[string]interface{}{"id": 7, "ids": []int{1, 2}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"id": float64(7), "tagIds": []interface{}{float64(1), float64(2)}}, r.Node.Args)
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	defer setUpSchema(t)()
+
+	for query, message := range map[string]string{
+		`{ book { id } author { id } }`:     "Only one root field is supported. (author)",
+		`{ b: book { id } }`:                "Aliases are not supported. (b)",
+		`{ book(id: $id) { id } }`:          "`$id` variable is not defined.",
+		`{ book { ...fields } }`:            "Fragments are not supported.",
+		`subscription { book { id } }`:      "`subscription` operation is not supported.",
+		`{ book { id }`:                     "The operation is not closed.",
+		`{ book { id`:                       "`book` is not closed.",
+		`{ book(title: "unclosed) { id } }`: "A string is not closed.",
+	} {
+		_, err := ParseQuery(query, nil)
+
+		if assert.NotNil(t, err, query) {
+			assert.Equal(t, message, err.Error(), query)
+		}
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	r, err := ParseJSON([]byte(`{"name":"anonymous","operation":"query","node":{"name":"book","type":"Book","fields":{"id":{"name":"id","type":"Int","isLeaf":true}}}}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Book", r.Node.Type)
+	assert.True(t, r.Node.Fields["id"].IsLeaf)
+
+	_, err = ParseJSON([]byte(`{"name":"anonymous"}`))
+	assert.NotNil(t, err)
+}
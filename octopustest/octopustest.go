@@ -0,0 +1,178 @@
+package octopustest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/farmer"
+	"github.com/finwhale/octopus/request"
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+type (
+	// 테스트에서 사용하는 가짜 사용자입니다.
+	User struct {
+		Id    interface{}
+		Roles []string
+		Props map[string]string
+	}
+
+	// 요청을 서버 없이 실행하고 결과를 골든 파일과 비교합니다.
+	Client struct {
+		T    testing.TB
+		User request.CurrentUser // 없다면 익명 사용자로 실행합니다.
+	}
+)
+
+// ------------------------------
+// User
+// ------------------------------
+
+func (u *User) HasId(id interface{}) bool {
+	return fmt.Sprintf("%v", u.Id) == fmt.Sprintf("%v", id)
+}
+
+func (u *User) HasRole(role string) bool {
+	return core.Contains(u.Roles, role)
+}
+
+func (u *User) HasProp(key string, value string) bool {
+	return u.Props[key] == value
+}
+
+var _ request.CurrentUser = (*User)(nil)
+
+// ------------------------------
+// Setup
+// ------------------------------
+
+// 생성된 models 패키지 없이 모델들을 등록합니다. 모델의 이름은 구조체의 이름입니다.
+func RegisterModels(models ...interface{}) {
+	registered := map[string]interface{}{}
+
+	for _, model := range models {
+		registered[reflect.Indirect(reflect.ValueOf(model)).Type().Name()] = model
+	}
+
+	request.GetAllFunc = func() map[string]interface{} {
+		return registered
+	}
+
+	request.GetFunc = func(candidate string) interface{} {
+		return registered[core.Classify(candidate)]
+	}
+
+	request.NewFunc = func(candidate string, isList bool) interface{} {
+		model, exist := registered[core.Classify(candidate)]
+
+		if !exist {
+			return nil
+		}
+
+		t := reflect.Indirect(reflect.ValueOf(model)).Type()
+
+		if isList {
+			list := reflect.New(reflect.SliceOf(t))
+			list.Elem().Set(reflect.MakeSlice(list.Elem().Type(), 0, 0))
+
+			return list.Interface()
+		}
+
+		return reflect.New(t).Interface()
+	}
+}
+
+// 테스트 데이터베이스를 비우고 픽스쳐를 저장합니다. 픽스쳐 디렉토리를 지정하지 않으면 픽스쳐를 저장하지 않습니다.
+func SetUpDB(fixturesDir string) *core.Fixtures {
+	db := core.SetTestDB()
+	core.DropTestDB()
+
+	if fixturesDir == "" {
+		return nil
+	}
+
+	return core.LoadFixtures(db, fixturesDir)
+}
+
+// ------------------------------
+// Client
+// ------------------------------
+
+func New(t testing.TB) *Client {
+	return &Client{T: t}
+}
+
+// 다른 사용자로 요청하는 클라이언트를 반환합니다.
+func (c *Client) As(user request.CurrentUser) *Client {
+	return &Client{T: c.T, User: user}
+}
+
+// GraphQL 문서를 요청으로 만들어 실행하고 응답을 반환합니다.
+func (c *Client) Query(query string, variables map[string]interface{}) interface{} {
+	c.T.Helper()
+
+	r, err := ParseQuery(query, variables)
+
+	if err != nil {
+		c.T.Fatalf("Can not parse the query: %v", err)
+	}
+
+	return c.Exec(r)
+}
+
+// 요청을 실행하고 JSON 으로 변환했을 때와 같은 형태의 응답을 반환합니다. 패닉이 발생하면 테스트를 실패시킵니다.
+func (c *Client) Exec(r *request.Request) interface{} {
+	c.T.Helper()
+
+	response, err := Exec(r, c.User)
+
+	if err != nil {
+		c.T.Fatal(err)
+	}
+
+	return response
+}
+
+// GraphQL 문서를 실행하고 응답을 `testdata/<name>.golden.json`과 비교합니다.
+func (c *Client) Golden(name string, query string, variables map[string]interface{}) {
+	c.T.Helper()
+
+	AssertGolden(c.T, name, c.Query(query, variables))
+}
+
+// 요청을 실행합니다. 사용자를 지정하면 데이터베이스에서 사용자를 찾지 않습니다.
+func Exec(r *request.Request, user request.CurrentUser) (response interface{}, err error) {
+	if user != nil {
+		r.SetUser(user)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v\n%s", recovered, debug.Stack())
+		}
+	}()
+
+	result := farmer.Exec(r)
+
+	if casted, ok := result.(*request.Result); ok {
+		result = casted.Data
+	}
+
+	return normalize(result)
+}
+
+// 응답을 JSON 으로 변환했다가 되돌려 서버의 응답과 같은 형태로 만듭니다.
+func normalize(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized interface{}
+	err = json.Unmarshal(b, &normalized)
+
+	return normalized, err
+}
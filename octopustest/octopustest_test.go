@@ -0,0 +1,70 @@
+package octopustest
+
+import (
+	"flag"
+	"github.com/finwhale/octopus/request"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type Book struct {
+	Id    int64
+	Title string
+}
+
+func TestRegisterModels(t *testing.T) {
+	getFunc, getAllFunc, newFunc := request.GetFunc, request.GetAllFunc, request.NewFunc
+	defer func() { request.GetFunc, request.GetAllFunc, request.NewFunc = getFunc, getAllFunc, newFunc }()
+
+	RegisterModels(&Book{})
+
+	assert.Equal(t, &Book{}, request.Get("book"))
+	assert.Len(t, request.GetAll(), 1)
+	assert.Equal(t, &Book{}, request.New("Book", false))
+	assert.Equal(t, &[]Book{}, request.New("Book", true))
+	assert.Nil(t, request.New("Author", false))
+}
+
+func TestUser(t *testing.T) {
+	u := &User{Id: 3, Roles: []string{"admin"}, Props: map[string]string{"team": "core"}}
+
+	assert.True(t, u.HasId(int64(3)))
+	assert.False(t, u.HasId(4))
+	assert.True(t, u.HasRole("admin"))
+	assert.False(t, u.HasRole("user"))
+	assert.True(t, u.HasProp("team", "core"))
+	assert.False(t, u.HasProp("team", "web"))
+}
+
+func TestAssertGolden(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golden")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	goldenDir, update := GoldenDir, *Update
+	defer func() { GoldenDir, *Update = goldenDir, update }()
+	GoldenDir = dir
+
+	response := map[string]interface{}{"title": "Go", "id": 1}
+
+	*Update = true
+	AssertGolden(t, "books/first", response)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "books", "first.golden.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"id\": 1,\n  \"title\": \"Go\"\n}\n", string(b))
+
+	*Update = false
+	AssertGolden(t, "books/first", response)
+
+	mock := &testing.T{}
+	AssertGolden(mock, "books/first", map[string]interface{}{"title": "Rust", "id": 1})
+	assert.True(t, mock.Failed())
+
+	// 사용하는 패키지가 `-update` 플래그를 정의할 수 있도록 패키지 이름을 붙인 플래그를 사용합니다.
+	assert.Nil(t, flag.Lookup("update"))
+	assert.NotNil(t, flag.Lookup("octopustest.update"))
+}
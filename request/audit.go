@@ -0,0 +1,259 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/jinzhu/gorm"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	// 뮤테이션으로 변경된 레코드 하나에 대한 감사 기록입니다.
+	AuditEntry struct {
+		UserId    interface{}                       `json:"userId"`
+		Model     string                            `json:"model"`
+		Key       interface{}                       `json:"key"`
+		Operation string                            `json:"operation"`
+		Diff      map[string]map[string]interface{} `json:"diff"` // 변경된 컬럼별 { before, after }
+		CreatedAt time.Time                         `json:"createdAt"`
+	}
+
+	// 감사 기록을 저장하는 곳입니다. 뮤테이션의 트랜잭션이 커밋되기 직전에 같은 트랜잭션으로 호출되며,
+	// 오류를 반환하면 뮤테이션 전체가 롤백됩니다. 트랜잭션에 참여할 수 없는 FileAuditSink 는 커밋된 후에 호출됩니다.
+	AuditSink interface {
+		Write(db *gorm.DB, entries []AuditEntry) error
+	}
+
+	// 데이터베이스의 테이블에 감사 기록을 저장합니다. 테이블은 user_id, model, primary_key,
+	// operation, diff, created_at 컬럼을 가지고 있어야 합니다.
+	TableAuditSink struct {
+		Table string
+	}
+
+	// 로컬 파일에 감사 기록을 한 줄에 하나씩 JSON 형태로 저장합니다. 롤백된 뮤테이션이 기록되지 않도록
+	// 기록은 트랜잭션이 커밋된 후에 저장됩니다.
+	FileAuditSink struct {
+		Path  string
+		mutex sync.Mutex
+	}
+)
+
+var (
+	auditSink       AuditSink
+	isAuditSinkSet  bool
+	auditSinkLocker sync.Mutex
+)
+
+const DefaultAuditTable = "audit_log"
+
+// authority.yaml 에 설정하지 않은 감사 기록 모델은 관리자만 조회할 수 있습니다.
+var auditAuthorityModel = AuthorityModel{
+	Read:    Permission{Default: Validator{Expression: "hasRole", Values: []string{"admin"}}},
+	Write:   Permission{Default: Validator{Expression: "hasRole", Values: []string{"admin"}}},
+	Deleted: Validator{Expression: "hasRole", Values: []string{"admin"}},
+}
+
+// 감사 기록을 저장할 곳을 직접 지정합니다. nil 을 지정하면 감사 기록을 남기지 않습니다.
+func SetAuditSink(sink AuditSink) {
+	auditSinkLocker.Lock()
+	defer auditSinkLocker.Unlock()
+
+	auditSink = sink
+	isAuditSinkSet = true
+}
+
+// 설정된 감사 기록 저장소를 반환합니다. 직접 지정하지 않았다면 `config.yaml`의 audit 설정을 따릅니다.
+func GetAuditSink() AuditSink {
+	auditSinkLocker.Lock()
+	defer auditSinkLocker.Unlock()
+
+	if !isAuditSinkSet {
+		audit := core.GetConfig(false).Audit
+
+		if audit.Table != "" {
+			auditSink = &TableAuditSink{Table: audit.Table}
+		} else if audit.File != "" {
+			auditSink = &FileAuditSink{Path: audit.File}
+		}
+
+		isAuditSinkSet = true
+	}
+
+	return auditSink
+}
+
+// 감사 기록을 저장하는 테이블은 뮤테이션으로 변경할 수 없습니다.
+func IsAuditModel(modelName string) bool {
+	sink, ok := GetAuditSink().(*TableAuditSink)
+
+	return ok && core.IsSimilar(sink.Table, modelName)
+}
+
+// ------------------------------
+// Request
+// ------------------------------
+
+// 커밋될 때 저장될 감사 기록을 추가합니다. 커스텀 뮤테이션에서도 사용할 수 있습니다.
+// 행위자는 요청의 userId 가 아니라 실제로 찾은 사용자의 기본키이며, 익명 사용자라면 비워둡니다.
+func (r *Request) Audit(entry AuditEntry) {
+	if entry.UserId == nil {
+		entry.UserId = r.GetUserId()
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	r.audits = append(r.audits, entry)
+}
+
+// 쌓인 감사 기록을 요청의 트랜잭션으로 저장합니다. FileAuditSink 라면 커밋된 후에 저장하도록 남겨둡니다.
+func (r *Request) WriteAudits() error {
+	sink := GetAuditSink()
+	entries := r.audits
+	r.audits = nil
+
+	if sink == nil || len(entries) == 0 {
+		return nil
+	}
+
+	if _, ok := sink.(*FileAuditSink); ok {
+		r.committedAudits = append(r.committedAudits, entries...)
+		return nil
+	}
+
+	return sink.Write(r.DB(), entries)
+}
+
+// 커밋된 트랜잭션의 감사 기록을 파일에 저장합니다.
+func (r *Request) writeCommittedAudits() error {
+	sink := GetAuditSink()
+
+	if sink == nil || len(r.committedAudits) == 0 {
+		return nil
+	}
+
+	if err := sink.Write(nil, r.committedAudits); err != nil {
+		return fmt.Errorf("The mutation has been committed, but the audit log could not be written. (%v)", err)
+	}
+
+	return nil
+}
+
+// ------------------------------
+// Node
+// ------------------------------
+
+// 변경 전후의 레코드를 비교하여 감사 기록을 추가합니다.
+func (n *Node) audit(table *core.Table, id interface{}, before interface{}, after interface{}) {
+	if GetAuditSink() == nil || n.Request == nil {
+		return
+	}
+
+	n.Request.Audit(AuditEntry{
+		Model:     core.Classify(table.Name),
+		Key:       id,
+		Operation: n.action(),
+		Diff:      Diff(table, before, after),
+	})
+}
+
+// 기본키로 레코드를 불러옵니다. 삭제 여부와 관계없이 찾으며, 없다면 nil 을 반환합니다.
+func (n *Node) load(table *core.Table, primary string, id interface{}) interface{} {
+	if GetAuditSink() == nil {
+		return nil
+	}
+
+	model := New(n.Type, false)
+
+	if n.DB().Where(fmt.Sprintf("`%v`.`%v` = ?", table.Name, primary), id).First(model).RecordNotFound() {
+		return nil
+	}
+
+	return model
+}
+
+// ------------------------------
+// Sink
+// ------------------------------
+
+func (s *TableAuditSink) Write(db *gorm.DB, entries []AuditEntry) error {
+	query := fmt.Sprintf(
+		"INSERT INTO `%v` (`user_id`, `model`, `primary_key`, `operation`, `diff`, `created_at`) VALUES (?, ?, ?, ?, ?, ?)",
+		s.Table,
+	)
+
+	for _, entry := range entries {
+		diff, err := json.Marshal(entry.Diff)
+
+		if err != nil {
+			return err
+		}
+
+		key := fmt.Sprintf("%v", entry.Key)
+		err = db.Exec(query, entry.UserId, entry.Model, key, entry.Operation, string(diff), entry.CreatedAt).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *FileAuditSink) Write(_ *gorm.DB, entries []AuditEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	for _, entry := range entries {
+		if err = encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	return f.Sync()
+}
+
+// ------------------------------
+// Utils
+// ------------------------------
+
+// 테이블의 컬럼들을 기준으로 변경 전후의 값이 다른 컬럼들을 찾습니다. 생성이나 삭제처럼 한쪽이 nil 이면
+// 다른 쪽의 모든 컬럼이 변경된 것으로 봅니다.
+func Diff(table *core.Table, before interface{}, after interface{}) map[string]map[string]interface{} {
+	diff := map[string]map[string]interface{}{}
+
+	for _, column := range table.Columns {
+		name := core.CamelCase(column.Name)
+		var b, a interface{}
+
+		if before != nil {
+			b = core.Get(before, name)
+		}
+
+		if after != nil {
+			a = core.Get(after, name)
+		}
+
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+
+		diff[name] = map[string]interface{}{"before": b, "after": a}
+	}
+
+	return diff
+}
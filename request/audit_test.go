@@ -0,0 +1,191 @@
+package request
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/finwhale/octopus/core"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+type auditUser struct {
+	Id int64
+}
+
+func (u *auditUser) HasId(id interface{}) bool {
+	return u.Id == id
+}
+
+func (u *auditUser) HasRole(role string) bool {
+	return false
+}
+
+func (u *auditUser) HasProp(key string, value string) bool {
+	return false
+}
+
+type auditBook struct {
+	Id    int64
+	Title string
+	Price int64
+}
+
+func TestDiff(t *testing.T) {
+	table := &core.Table{
+		Name: "book",
+		Columns: map[string]*core.Column{
+			"id":    &core.Column{Name: "id"},
+			"title": &core.Column{Name: "title"},
+			"price": &core.Column{Name: "price"},
+		},
+	}
+
+	before := &auditBook{Id: 1, Title: "Octopus", Price: 100}
+	after := &auditBook{Id: 1, Title: "Octopus", Price: 200}
+
+	assert.Equal(t, Diff(table, before, after), map[string]map[string]interface{}{
+		"price": {"before": int64(100), "after": int64(200)},
+	})
+
+	created := Diff(table, nil, after)
+	assert.Len(t, created, 3)
+	assert.Equal(t, created["title"], map[string]interface{}{"before": nil, "after": "Octopus"})
+
+	deleted := Diff(table, before, nil)
+	assert.Len(t, deleted, 3)
+	assert.Equal(t, deleted["id"], map[string]interface{}{"before": int64(1), "after": nil})
+}
+
+func TestFileAuditSink_Write(t *testing.T) {
+	dir, err := ioutil.TempDir("", "octopus")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sink := &FileAuditSink{Path: path.Join(dir, "audit.jsonl")}
+	err = sink.Write(nil, []AuditEntry{
+		{UserId: 1, Model: "Book", Key: 3, Operation: CREATE},
+		{UserId: 1, Model: "Book", Key: 3, Operation: DELETE},
+	})
+	assert.Nil(t, err)
+
+	err = sink.Write(nil, []AuditEntry{{UserId: 2, Model: "Book", Key: 4, Operation: UPDATE}})
+	assert.Nil(t, err)
+
+	f, err := os.Open(sink.Path)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	var operations []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &entry))
+		operations = append(operations, entry.Operation)
+	}
+
+	assert.Equal(t, operations, []string{CREATE, DELETE, UPDATE})
+}
+
+type memoryAuditSink struct {
+	entries []AuditEntry
+}
+
+func (s *memoryAuditSink) Write(_ *gorm.DB, entries []AuditEntry) error {
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func TestRequest_WriteAudits(t *testing.T) {
+	sink := &memoryAuditSink{}
+	SetAuditSink(sink)
+	defer SetAuditSink(nil)
+
+	r := &Request{UserId: 7, tx: &gorm.DB{}}
+	r.SetUser(&auditUser{Id: 7})
+	r.Audit(AuditEntry{Model: "Book", Key: 1, Operation: UPDATE})
+
+	assert.Nil(t, r.WriteAudits())
+	assert.Len(t, sink.entries, 1)
+	assert.Equal(t, sink.entries[0].UserId, int64(7))
+	assert.False(t, sink.entries[0].CreatedAt.IsZero())
+
+	assert.Nil(t, r.WriteAudits())
+	assert.Len(t, sink.entries, 1)
+
+	// 존재하지 않는 사용자의 ID 를 보낸 요청은 익명 사용자로 기록됩니다.
+	r = &Request{UserId: "999", tx: &gorm.DB{}}
+	r.SetUser(&AnonymousUser{})
+	r.Audit(AuditEntry{Model: "Book", Key: 1, Operation: UPDATE})
+
+	assert.Nil(t, r.WriteAudits())
+	assert.Len(t, sink.entries, 2)
+	assert.Nil(t, sink.entries[1].UserId)
+}
+
+func TestRequest_WriteAudits_File(t *testing.T) {
+	dir, err := ioutil.TempDir("", "octopus")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sink := &FileAuditSink{Path: path.Join(dir, "audit.jsonl")}
+	SetAuditSink(sink)
+	defer SetAuditSink(nil)
+
+	r := &Request{}
+	r.SetUser(&auditUser{Id: 7})
+	r.Audit(AuditEntry{Model: "Book", Key: 1, Operation: UPDATE})
+
+	// 파일은 트랜잭션에 참여할 수 없으므로 커밋되기 전에는 쓰지 않습니다.
+	assert.Nil(t, r.WriteAudits())
+	_, err = os.Stat(sink.Path)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Nil(t, r.writeCommittedAudits())
+	b, err := ioutil.ReadFile(sink.Path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"operation":"update"`)
+
+	// 롤백된 뮤테이션의 기록은 버려집니다.
+	r.Audit(AuditEntry{Model: "Book", Key: 2, Operation: DELETE})
+	assert.Nil(t, r.WriteAudits())
+	assert.Nil(t, r.Rollback())
+	assert.Empty(t, r.committedAudits)
+}
+
+func TestAuthority_AuditModel(t *testing.T) {
+	SetAuditSink(&TableAuditSink{Table: DefaultAuditTable})
+	defer SetAuditSink(nil)
+
+	r := &Request{Operation: "query", Node: &Node{
+		Name:   "auditLogList",
+		Type:   "AuditLog",
+		Fields: map[string]*Node{"diff": {Name: "diff", Type: "String"}},
+	}}
+	r.SetUp()
+
+	authority := parseAuthority(map[string]interface{}{"default": ""})
+	validatorMap, _ := authority.AnalyzeRead(r.Node)
+	assert.Equal(t, validatorMap["diff"], []Validator{{Expression: "hasRole", Values: []string{"admin"}}})
+
+	// authority.yaml 에 설정했다면 그것을 따릅니다.
+	authority = parseAuthority(map[string]interface{}{
+		"models": map[string]interface{}{"auditLog": "hasRole(\"auditor\")"},
+	})
+	validatorMap, _ = authority.AnalyzeRead(r.Node)
+	assert.Equal(t, validatorMap["diff"], []Validator{{Expression: "hasRole", Values: []string{"auditor"}}})
+}
+
+func TestIsAuditModel(t *testing.T) {
+	SetAuditSink(&TableAuditSink{Table: DefaultAuditTable})
+	defer SetAuditSink(nil)
+
+	assert.True(t, IsAuditModel("AuditLog"))
+	assert.False(t, IsAuditModel("Book"))
+
+	_, err := (&Node{Name: "deleteAuditLog", Type: "AuditLog"}).Mutate()
+	assert.EqualError(t, err, "`AuditLog` is read-only.")
+}
@@ -17,13 +17,15 @@ type (
 	}
 
 	DefaultAuthority struct {
-		Read  Validator
-		Write Validator
+		Read    Validator
+		Write   Validator
+		Deleted Validator
 	}
 
 	AuthorityModel struct {
-		Read  Permission // 읽기 권한
-		Write Permission // 쓰기 권한
+		Read    Permission // 읽기 권한
+		Write   Permission // 쓰기 권한
+		Deleted Validator  // 삭제된 레코드를 조회할 권한, 없다면 쓰기 권한을 따른다.
 	}
 
 	Permission struct {
@@ -73,30 +75,36 @@ func (a *Authority) Analyze(n *Node) (validatorMap map[string][]Validator, persi
 	isRead := core.Classify(n.Request.Operation) == "Query"
 	isWrite := core.Classify(n.Request.Operation) == "Mutation"
 
-	if isRead {
+	// 뮤테이션의 결과로 반환되는 필드들도 읽기 권한으로 검증합니다.
+	if isRead || isWrite {
 		return a.AnalyzeRead(n)
 	}
 
-	if isWrite {
-		return a.AnalyzeWrite(n)
+	panic(fmt.Errorf("%v is an operation that can not be performed.", n.Request.Operation))
+}
+
+// 삭제된 레코드를 조회할 권한이 있는지 확인합니다.
+func (a *Authority) CanReadDeleted(n *Node) bool {
+	validator := a.Default.Deleted
+
+	if model := a.model(core.Classify(n.Type)); model != nil {
+		validator = model.Deleted
 	}
 
-	panic(fmt.Errorf("%v is an operation that can not be performed.", n.Request.Operation))
+	statusCode, _ := validator.ExecWrite(n.Request, n.Name, nil)
+
+	return statusCode == 200
 }
 
 func (a *Authority) AnalyzeRead(n *Node) (validatorMap map[string][]Validator, fields []string) {
 	var authorityModel *AuthorityModel
 
 	// 해당 노드에 대해 설정된 검증 객체가 있는지 찾습니다.
-	if model, exist := a.Models[n.Type]; exist {
-		authorityModel = &model
-	}
+	authorityModel = a.model(n.Type)
 
 	// 노드에 대한 인증이 없는 경우 부모노드에 대해 찾습니다.
 	if authorityModel == nil && n.Parent != nil {
-		if model, exist := a.Models[n.Parent.Type]; exist {
-			authorityModel = &model
-		}
+		authorityModel = a.model(n.Parent.Type)
 	}
 
 	validatorMap = map[string][]Validator{}
@@ -131,7 +139,47 @@ func (a *Authority) AnalyzeRead(n *Node) (validatorMap map[string][]Validator, f
 	return
 }
 
-func (a *Authority) AnalyzeWrite(n *Node) (validatorMap map[string][]Validator, persists []string) {
+// 모델에 설정된 권한을 찾습니다. 설정되지 않은 감사 기록 모델은 관리자만 조회할 수 있습니다.
+func (a *Authority) model(name string) *AuthorityModel {
+	if model, exist := a.Models[name]; exist {
+		return &model
+	}
+
+	if IsAuditModel(name) {
+		model := auditAuthorityModel
+		return &model
+	}
+
+	return nil
+}
+
+// 뮤테이션 노드가 변경하려는 인자들에 대한 쓰기 권한을 분석합니다.
+// 모델의 기본 쓰기 권한은 노드의 이름을 키로 하여 항상 포함됩니다.
+func (a *Authority) AnalyzeWrite(n *Node) (validatorMap map[string][]Validator, fields []string) {
+	validatorMap = map[string][]Validator{}
+	authorityModel, exist := a.Models[core.Classify(n.Type)]
+
+	if !exist {
+		validatorMap[n.Name] = []Validator{a.Default.Write}
+	} else {
+		validatorMap[n.Name] = []Validator{authorityModel.Write.Default}
+
+		for name := range n.Args {
+			if vds, exist := authorityModel.Write.Fields[core.CamelCase(name)]; exist {
+				validatorMap[name] = append(validatorMap[name], vds...)
+			}
+		}
+	}
+
+	for _, validators := range validatorMap {
+		for _, validator := range validators {
+			if validator.Field == "" || core.Contains(fields, validator.Field) {
+				continue
+			}
+			fields = append(fields, validator.Field)
+		}
+	}
+
 	return
 }
 
@@ -160,6 +208,12 @@ func parseAuthority(raw interface{}) Authority {
 					a.Default.Write = parseValidator(v.(string))
 				}
 			}
+
+			a.Default.Deleted = a.Default.Write
+
+			if defaultMap := core.ParseMap(defaults); defaultMap["deleted"] != nil {
+				a.Default.Deleted = parseValidator(defaultMap["deleted"].(string))
+			}
 		}
 
 		if models, exist := rawMap["models"]; exist {
@@ -199,6 +253,12 @@ func parseAuthorityModel(raw interface{}, defaults DefaultAuthority) AuthorityMo
 		}
 	}
 
+	a.Deleted = a.Write.Default
+
+	if m := core.ParseMap(raw); m["deleted"] != nil {
+		a.Deleted = parseValidator(m["deleted"].(string))
+	}
+
 	return a
 }
 
@@ -290,6 +350,44 @@ func (m *Validator) Exec(n *Node, model interface{}) (statusCode int, errorMessa
 	return 401, fmt.Sprintf("No permission to read `%v`.", n.Name)
 }
 
+// 레코드를 변경할 권한이 있는지 검증합니다. hasId 는 레코드에서 검증 필드의 값을 찾아 비교하며, 레코드가 없다면 거부합니다.
+func (m *Validator) ExecWrite(r *Request, name string, record interface{}) (statusCode int, errorMessage string) {
+	if m.IsAll() {
+		return 200, ""
+	}
+
+	method := reflect.ValueOf(r.GetUser()).MethodByName(core.Classify(m.Expression))
+
+	if !method.IsValid() {
+		panic(fmt.Errorf("%v - `%v` is invalid expression.", name, m.Expression))
+	}
+
+	var args []reflect.Value
+	if m.IsHasId() {
+		if record == nil {
+			return 401, fmt.Sprintf("No permission to write `%v`.", name)
+		}
+
+		value := core.Get(record, m.Field)
+
+		if value == nil {
+			return 401, fmt.Sprintf("No permission to write `%v`.", name)
+		}
+
+		args = append(args, reflect.ValueOf(value))
+	} else if m.IsHasRole() {
+		for _, value := range m.Values {
+			args = append(args, reflect.ValueOf(value))
+		}
+	}
+
+	if method.Call(args)[0].Bool() {
+		return 200, ""
+	}
+
+	return 401, fmt.Sprintf("No permission to write `%v`.", name)
+}
+
 func parseValidator(raw string) Validator {
 	m := Validator{}
 	fieldRegex := regexp.MustCompile("\\(\\.([a-zA-Z]+)\\)")
@@ -398,3 +398,134 @@ func TestParseValidator_Invalid(t *testing.T) {
 	assert.Panics(t, func() { parseValidator(invalidValue) })
 	assert.Panics(t, func() { parseValidator(invalidExpress) })
 }
+
+func TestAuthority_AnalyzeWrite(t *testing.T) {
+	r := Request{
+		Name:      "anonymous",
+		Operation: "mutation",
+		Node: &Node{
+			Name: "updateUser",
+			Type: "User",
+			Args: map[string]interface{}{
+				"id":       1,
+				"about":    "foo",
+				"password": "bar",
+			},
+		},
+	}
+
+	r.SetUp()
+
+	authority := parseAuthority(map[string]interface{}{
+		"default": "hasRole(\"admin\")",
+		"models": map[string]interface{}{
+			"user": map[string]interface{}{
+				"write": map[string]interface{}{
+					"default": "hasId(.id)",
+					"fields": map[string]interface{}{
+						"password": "hasRole(\"admin\")",
+					},
+				},
+			},
+		},
+	})
+
+	validatorMap, fields := authority.AnalyzeWrite(r.Node)
+	assert.Len(t, validatorMap, 2)
+	assert.Contains(t, validatorMap["updateUser"], Validator{Expression: "hasId", Field: "id"})
+	assert.Contains(t, validatorMap["password"], Validator{Expression: "hasRole", Values: []string{"admin"}})
+	assert.Equal(t, fields, []string{"id"})
+}
+
+func TestAuthority_AnalyzeWrite_Default(t *testing.T) {
+	r := Request{
+		Name:      "anonymous",
+		Operation: "mutation",
+		Node:      &Node{Name: "createBook", Type: "Book"},
+	}
+
+	r.SetUp()
+
+	authority := parseAuthority(map[string]interface{}{
+		"default": "hasRole(\"admin\")",
+	})
+
+	validatorMap, fields := authority.AnalyzeWrite(r.Node)
+	assert.Len(t, validatorMap, 1)
+	assert.Contains(t, validatorMap["createBook"], Validator{Expression: "hasRole", Values: []string{"admin"}})
+	assert.Empty(t, fields)
+}
+
+func TestValidator_ExecWrite(t *testing.T) {
+	r := &Request{user: &AnonymousUser{}}
+
+	all := Validator{}
+	statusCode, message := all.ExecWrite(r, "user", nil)
+	assert.Equal(t, statusCode, 200)
+	assert.Empty(t, message)
+
+	anonymous := parseValidator("hasRole(\"anonymous\")")
+	statusCode, message = anonymous.ExecWrite(r, "user", nil)
+	assert.Equal(t, statusCode, 200)
+	assert.Empty(t, message)
+
+	owner := parseValidator("hasId(.userId)")
+	statusCode, message = owner.ExecWrite(r, "book", map[string]interface{}{"userId": 1})
+	assert.Equal(t, statusCode, 401)
+	assert.Equal(t, message, "No permission to write `book`.")
+}
+
+func TestParseAuthority_Deleted(t *testing.T) {
+	authority := parseAuthority(map[string]interface{}{
+		"default": map[string]interface{}{
+			"read":  "hasRole(\"user\")",
+			"write": "hasRole(\"editor\")",
+		},
+		"models": map[string]interface{}{
+			"user": map[string]interface{}{
+				"deleted": "hasRole(\"admin\")",
+			},
+			"book": "hasRole(\"author\")",
+		},
+	})
+
+	assert.Equal(t, authority.Default.Deleted, Validator{Expression: "hasRole", Values: []string{"editor"}})
+	assert.Equal(t, authority.Models["User"].Deleted, Validator{Expression: "hasRole", Values: []string{"admin"}})
+	assert.Equal(t, authority.Models["Book"].Deleted, Validator{Expression: "hasRole", Values: []string{"author"}})
+}
+
+func TestAuthority_CanReadDeleted(t *testing.T) {
+	r := &Request{user: &AnonymousUser{}, Node: &Node{Name: "userList", Type: "User"}}
+	r.SetUp()
+
+	authority := parseAuthority(map[string]interface{}{
+		"default": "hasRole(\"anonymous\")",
+		"models": map[string]interface{}{
+			"user": map[string]interface{}{
+				"deleted": "hasRole(\"admin\")",
+			},
+		},
+	})
+
+	assert.False(t, authority.CanReadDeleted(r.Node))
+
+	r.Node.Type = "Book"
+	assert.True(t, authority.CanReadDeleted(r.Node))
+}
+
+func TestAuthority_CanReadDeleted_HasId(t *testing.T) {
+	r := &Request{user: &AnonymousUser{}, Node: &Node{Name: "bookList", Type: "Book"}}
+	r.SetUp()
+
+	authority := parseAuthority(map[string]interface{}{
+		"default": "hasId(.userId)",
+	})
+
+	assert.Equal(t, authority.Default.Deleted, Validator{Expression: "hasId", Field: "userId"})
+	assert.False(t, authority.CanReadDeleted(r.Node))
+
+	owner := parseValidator("hasId(.userId)")
+	statusCode, message := owner.ExecWrite(r, "book", nil)
+	assert.Equal(t, statusCode, 401)
+	assert.Equal(t, message, "No permission to write `book`.")
+}
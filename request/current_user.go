@@ -1,5 +1,9 @@
 package request
 
+import (
+	"github.com/finwhale/octopus/core"
+)
+
 var UserModelName string
 
 type (
@@ -42,3 +46,34 @@ var _ CurrentUser = (*AnonymousUser)(nil)
 func SetUserModelName(modelName string) {
 	UserModelName = modelName
 }
+
+// 설정된 사용자 모델명이 없다면 "User"를 기본값으로 사용한다.
+func userModelName() string {
+	if UserModelName == "" {
+		return DEFAULT_USER
+	}
+
+	return UserModelName
+}
+
+// 요청한 사용자의 기본키 값을 반환합니다. 익명 사용자라면 nil 을 반환합니다.
+func (r *Request) GetUserId() interface{} {
+	user := r.GetUser()
+
+	if _, ok := user.(*AnonymousUser); ok {
+		return nil
+	}
+
+	primary := "id"
+
+	if p, err := core.GetSchema(false).GetPrimary(userModelName()); err == nil {
+		primary = p
+	}
+
+	return core.Get(user, core.CamelCase(primary))
+}
+
+// 데이터베이스에서 사용자를 찾지 않고 요청의 사용자를 직접 지정합니다. 테스트에서 가짜 사용자를 사용할 때 유용합니다.
+func (r *Request) SetUser(user CurrentUser) {
+	r.user = user
+}
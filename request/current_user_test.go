@@ -42,3 +42,10 @@ func TestAnonymousUser_String(t *testing.T) {
 
 	assert.Equal(t, u.String(), "anonymous")
 }
+
+func TestRequest_SetUser(t *testing.T) {
+	r := &Request{UserId: 1}
+	r.SetUser(AnonymousUser{})
+
+	assert.Equal(t, AnonymousUser{}, r.GetUser())
+}
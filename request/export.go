@@ -0,0 +1,325 @@
+package request
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/jinzhu/gorm"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	EXPORT_FORMAT           = "_format"
+	NDJSON                  = "ndjson"
+	CSV                     = "csv"
+	DefaultExportChunkSize  = 500
+	exportErrorSeparator    = "; "
+	exportContentTypeNDJSON = "application/x-ndjson"
+	exportContentTypeCSV    = "text/csv; charset=utf-8"
+)
+
+type (
+	// 내보내기의 한 레코드를 형식에 맞게 씁니다.
+	exportWriter interface {
+		Write(row map[string]interface{}) error
+		Flush() error
+	}
+
+	ndjsonWriter struct {
+		encoder *json.Encoder
+	}
+
+	csvWriter struct {
+		writer *csv.Writer
+		fields []string
+		header bool
+	}
+
+	flusher interface {
+		Flush()
+	}
+)
+
+// 내보내기 형식의 Content-Type 을 반환합니다. 지원하지 않는 형식이라면 오류를 반환합니다.
+func ExportContentType(format string) (string, error) {
+	switch format {
+	case NDJSON:
+		return exportContentTypeNDJSON, nil
+	case CSV:
+		return exportContentTypeCSV, nil
+	}
+
+	return "", newRequestError(400, "`%v` is not support export format. (%v, %v)", format, NDJSON, CSV)
+}
+
+// 목록 노드를 페이지 제한 없이 NDJSON 또는 CSV 로 스트리밍합니다.
+// 결과는 chunkSize 개씩 별도의 쿼리로 읽어 FulFill 과 벌크 메서드를 적용한 뒤 바로 쓰므로 메모리 사용량이 일정하고,
+// 커서로 연결을 붙잡지 않으므로 커스텀 메서드의 쿼리와 동시에 실행되어도 연결 풀이 고갈되지 않습니다.
+// `_order`가 없다면 기본키를 기준으로(WHERE pk > 마지막 값) 읽고, 있다면 기본키를 보조 정렬로 두어 OFFSET 으로 읽습니다.
+// 권한은 일반 요청과 같이 행마다 검증되며 거부된 필드는 `_error`에 담깁니다. `_limit`, `_offset`을 지정하면 그대로 적용합니다.
+func (n *Node) Export(w io.Writer, format string, chunkSize int) (err error) {
+	if !n.IsList && !n.IsPlainList {
+		return newRequestError(400, "`%v` is not a list. Only a list can be exported.", n.Name)
+	}
+
+	writer, err := newExportWriter(w, format, n.exportFields())
+
+	if err != nil {
+		return err
+	}
+
+	if err = n.validateDeleted(); err != nil {
+		return err
+	}
+
+	defer n.observe()()
+
+	n.Analyze(false)
+	db, _ := n.Query(false)
+
+	return n.exportChunks(w, writer, chunkSize, n.exportFetcher(db))
+}
+
+// 내보낼 묶음 하나를 읽습니다. after 는 직전 묶음의 마지막 기본키 값이며 첫 묶음에서는 nil 입니다.
+type exportFetcher func(after interface{}, written int, size int) (models interface{}, err error)
+
+func (n *Node) exportFetcher(db *gorm.DB) exportFetcher {
+	schema := core.GetSchema(false)
+	table := schema.MustTable(n.Type)
+	primary, primaryErr := schema.GetPrimary(n.Type)
+	column := fmt.Sprintf("`%v`.`%v`", table.Name, primary)
+	keyset := primaryErr == nil && len(n.Orders) == 0
+	offset := 0
+
+	if value, ok := n.Args[OFFSET]; ok && core.IsKindOf(value, reflect.Float64) {
+		offset = int(value.(float64))
+	}
+
+	// Fetch 와 같이 최상위 노드는 조인으로 같은 레코드가 중복되지 않도록 기본키로 묶습니다.
+	if n.Parent == nil && primaryErr == nil {
+		db = db.Group(column)
+	}
+
+	if keyset {
+		db = db.Order(column + " " + ASC)
+	} else if tiebreaker, ok := n.tiebreaker(); ok {
+		db = db.Order(tiebreaker.Query)
+	}
+
+	return func(after interface{}, written int, size int) (interface{}, error) {
+		models := New(n.Type, true)
+		chunkDB := db.Limit(size)
+
+		if keyset && after != nil {
+			chunkDB = chunkDB.Where(column+" > ?", after)
+		} else if keyset {
+			chunkDB = chunkDB.Offset(offset)
+		} else {
+			chunkDB = chunkDB.Offset(offset + written)
+		}
+
+		return models, chunkDB.Find(models).Error
+	}
+}
+
+// 묶음을 읽을 수 없거나 `_limit`에 도달할 때까지 묶음마다 FulFill 과 벌크 메서드를 적용하여 씁니다.
+func (n *Node) exportChunks(w io.Writer, writer exportWriter, chunkSize int, fetch exportFetcher) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultExportChunkSize
+	}
+
+	limit := -1
+
+	if value, ok := n.Args[LIMIT]; ok && core.IsKindOf(value, reflect.Float64) {
+		limit = int(value.(float64))
+	}
+
+	primary, _ := core.GetSchema(false).GetPrimary(n.Type)
+	var after interface{}
+
+	for written := 0; limit < 0 || written < limit; {
+		size := chunkSize
+
+		if limit >= 0 && limit-written < size {
+			size = limit - written
+		}
+
+		models, err := fetch(after, written, size)
+
+		if err != nil {
+			return err
+		}
+
+		chunk := reflect.ValueOf(models).Elem()
+
+		if chunk.Len() == 0 {
+			break
+		}
+
+		var data []map[string]interface{}
+
+		for i := 0; i < chunk.Len(); i++ {
+			data = append(data, doFulFill(n, chunk.Index(i).Addr().Interface()))
+		}
+
+		// 벌크 메서드는 읽은 레코드의 수만 사용하므로 연결을 사용하지 않는 빈 객체를 전달합니다.
+		n.Bulk(&gorm.DB{RowsAffected: int64(chunk.Len())}, models, data)
+
+		for _, row := range data {
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+
+		if f, ok := w.(flusher); ok {
+			f.Flush()
+		}
+
+		written += chunk.Len()
+		after = core.Get(chunk.Index(chunk.Len()-1).Addr().Interface(), core.CamelCase(primary))
+
+		if chunk.Len() < size {
+			break
+		}
+	}
+
+	// 레코드가 없더라도 CSV 의 첫 줄은 씁니다.
+	return writer.Flush()
+}
+
+// 응답을 쓰기 시작한 뒤 실패한 내보내기의 마지막에 오류를 씁니다. 클라이언트는 이것으로 잘린 응답을 구분할 수 있습니다.
+// NDJSON 은 오류 결과를 한 줄로, CSV 는 다른 열을 비우고 `_error` 열에 메시지를 담은 행을 씁니다.
+func (n *Node) WriteExportError(w io.Writer, format string, err error) error {
+	if format == CSV {
+		writer := csv.NewWriter(w)
+		record := make([]string, len(n.exportFields())+1)
+		record[len(record)-1] = err.Error()
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+
+		writer.Flush()
+
+		return writer.Error()
+	}
+
+	return json.NewEncoder(w).Encode(ErrorResult(n.Name, err))
+}
+
+// 내보낼 필드의 이름들을 정렬하여 반환합니다. `_total`과 같은 목록의 필드는 제외합니다.
+func (n *Node) exportFields() []string {
+	var names []string
+
+	for name := range n.Fields {
+		if !strings.HasPrefix(name, "_") {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func newExportWriter(w io.Writer, format string, fields []string) (exportWriter, error) {
+	if _, err := ExportContentType(format); err != nil {
+		return nil, err
+	}
+
+	if format == CSV {
+		return &csvWriter{writer: csv.NewWriter(w), fields: fields}, nil
+	}
+
+	return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+}
+
+func (w *ndjsonWriter) Write(row map[string]interface{}) error {
+	return w.encoder.Encode(row)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return nil
+}
+
+// 첫 줄은 필드의 이름이며 마지막 열은 권한 등으로 채워지지 않은 필드의 오류 메시지입니다.
+func (w *csvWriter) Write(row map[string]interface{}) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, 0, len(w.fields)+1)
+
+	for _, field := range w.fields {
+		value, err := csvValue(row[field])
+
+		if err != nil {
+			return err
+		}
+
+		record = append(record, value)
+	}
+
+	return w.writer.Write(append(record, csvErrors(row[ERROR])))
+}
+
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+
+	w.header = true
+
+	return w.writer.Write(append(append([]string{}, w.fields...), ERROR))
+}
+
+// 값을 JSON 응답과 같은 형태로 변환합니다. 문자열은 따옴표 없이, null 은 빈 칸으로 씁니다.
+func csvValue(value interface{}) (string, error) {
+	b, err := json.Marshal(value)
+
+	if err != nil {
+		return "", err
+	}
+
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return s, nil
+	}
+
+	return string(b), nil
+}
+
+func csvErrors(raw interface{}) string {
+	errorMap, ok := raw.(map[string]interface{})
+
+	if !ok {
+		return ""
+	}
+
+	errors, _ := errorMap[DATA].([]map[string]interface{})
+	var messages []string
+
+	for _, err := range errors {
+		messages = append(messages, fmt.Sprintf("%v", err["message"]))
+	}
+
+	return strings.Join(messages, exportErrorSeparator)
+}
@@ -0,0 +1,213 @@
+package request
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestExportContentType(t *testing.T) {
+	contentType, err := ExportContentType(CSV)
+	assert.Nil(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", contentType)
+
+	_, err = ExportContentType("xml")
+	assert.EqualError(t, err, "`xml` is not support export format. (ndjson, csv)")
+}
+
+func TestNode_Export(t *testing.T) {
+	n := &Node{Name: "book", Type: "Book"}
+	assert.EqualError(t, n.Export(&bytes.Buffer{}, NDJSON, 0), "`book` is not a list. Only a list can be exported.")
+
+	n = &Node{Name: "bookList", Type: "Book", IsList: true}
+	assert.EqualError(t, n.Export(&bytes.Buffer{}, "xml", 0), "`xml` is not support export format. (ndjson, csv)")
+}
+
+func TestNode_ExportFields(t *testing.T) {
+	n := &Node{Fields: map[string]*Node{"title": {}, "id": {}, "_count": {}}}
+
+	assert.Equal(t, []string{"id", "title"}, n.exportFields())
+}
+
+func TestExportWriter(t *testing.T) {
+	createdAt := time.Date(2017, 6, 17, 9, 30, 0, 0, time.UTC)
+	rows := []map[string]interface{}{
+		{"id": int64(1), "title": "Go, \"in\" Action", "createdAt": &createdAt, ERROR: map[string]interface{}{DATA: []map[string]interface{}{}, COUNT: 0}},
+		{"id": int64(2), "tags": []string{"a"}, ERROR: map[string]interface{}{
+			DATA:  []map[string]interface{}{{KEY: "title", "code": 403, "message": "No permission."}},
+			COUNT: 1,
+		}},
+	}
+
+	var b bytes.Buffer
+	writer, err := newExportWriter(&b, CSV, []string{"createdAt", "id", "tags", "title"})
+	assert.Nil(t, err)
+
+	for _, row := range rows {
+		assert.Nil(t, writer.Write(row))
+	}
+
+	assert.Nil(t, writer.Flush())
+	assert.Equal(t, "createdAt,id,tags,title,_error\n"+
+		"2017-06-17T09:30:00Z,1,,\"Go, \"\"in\"\" Action\",\n"+
+		",2,\"[\"\"a\"\"]\",,No permission.\n", b.String())
+
+	b.Reset()
+	writer, _ = newExportWriter(&b, NDJSON, nil)
+
+	for _, row := range rows {
+		assert.Nil(t, writer.Write(row))
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(b.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), `"title":"Go, \"in\" Action"`)
+
+	// 레코드가 없더라도 CSV 의 첫 줄은 씁니다.
+	b.Reset()
+	writer, _ = newExportWriter(&b, CSV, []string{"id"})
+	assert.Nil(t, writer.Flush())
+	assert.Equal(t, "id,_error\n", b.String())
+}
+
+func TestNode_WriteExportError(t *testing.T) {
+	n := &Node{Name: "bookList", Fields: map[string]*Node{"title": {}, "id": {}, "_count": {}}}
+
+	var b bytes.Buffer
+	assert.Nil(t, n.WriteExportError(&b, CSV, fmt.Errorf("Lost connection.")))
+	assert.Equal(t, ",,Lost connection.\n", b.String())
+
+	b.Reset()
+	assert.Nil(t, n.WriteExportError(&b, NDJSON, fmt.Errorf("Lost connection.")))
+	assert.Equal(t, `{"_error":{"_count":1,"_data":[{"code":500,"key":"bookList","message":"Lost connection."}]}}`+"\n", b.String())
+}
+
+type exportArticle struct {
+	FulFilled map[string]interface{}
+	Id        int64
+	UserId    int64
+	Text      string
+}
+
+func (a *exportArticle) GetSummary(_ *Node) interface{} {
+	return fmt.Sprintf("#%v", a.Id)
+}
+
+func (a *exportArticle) ScanCommentCount() []string {
+	return []string{"id"}
+}
+
+func (a *exportArticle) BulkCommentCount(_ *Node, extracted map[string]interface{}) ([]int, error) {
+	var counts []int
+
+	for _, id := range extracted["id"].([]interface{}) {
+		counts = append(counts, int(id.(int64))*10)
+	}
+
+	return counts, nil
+}
+
+type exportUser struct {
+	Id int64
+}
+
+func (u *exportUser) HasId(id interface{}) bool {
+	return id == u.Id
+}
+
+func (u *exportUser) HasRole(role string) bool {
+	return false
+}
+
+func (u *exportUser) HasProp(key string, value string) bool {
+	return false
+}
+
+func TestNode_ExportChunks(t *testing.T) {
+	getFunc, newFunc, previous := GetFunc, NewFunc, cachedAuthority
+	defer func() { GetFunc, NewFunc, cachedAuthority = getFunc, newFunc, previous }()
+
+	GetFunc = func(_ string) interface{} { return &exportArticle{} }
+	NewFunc = func(_ string, isList bool) interface{} {
+		if isList {
+			return &[]exportArticle{}
+		}
+		return &exportArticle{}
+	}
+
+	authority := parseAuthority(map[string]interface{}{
+		"models": map[string]interface{}{
+			"article": map[string]interface{}{"read": map[string]interface{}{"fields": map[string]interface{}{"userId": "hasId(.userId)"}}},
+		},
+	})
+	cachedAuthority = &authority
+
+	var articles []exportArticle
+	for i := int64(1); i <= 5; i++ {
+		articles = append(articles, exportArticle{Id: i, UserId: i % 2, Text: fmt.Sprintf("text %v", i)})
+	}
+
+	// 기본키가 after 보다 큰 레코드를 size 개 반환하는 가짜 쿼리입니다.
+	var calls [][]interface{}
+	fetch := func(after interface{}, written int, size int) (interface{}, error) {
+		calls = append(calls, []interface{}{after, written, size})
+		chunk := []exportArticle{}
+
+		for _, article := range articles {
+			if (after == nil || article.Id > after.(int64)) && len(chunk) < size {
+				chunk = append(chunk, article)
+			}
+		}
+
+		return &chunk, nil
+	}
+
+	newNode := func(args map[string]interface{}) *Node {
+		r := &Request{Operation: "query", Node: &Node{Name: "articleList", Type: "Article", IsList: true, Args: args, Fields: map[string]*Node{
+			"id":           {Name: "id", Type: "Int"},
+			"userId":       {Name: "userId", Type: "Int"},
+			"text":         {Name: "text", Type: "String"},
+			"summary":      {Name: "summary", Type: "String"},
+			"commentCount": {Name: "commentCount", Type: "Int"},
+		}}}
+		r.SetUp()
+		r.SetUser(&exportUser{Id: 1})
+		r.Node.Analyze(false)
+
+		return r.Node
+	}
+
+	var b bytes.Buffer
+	n := newNode(map[string]interface{}{})
+	writer, _ := newExportWriter(&b, CSV, n.exportFields())
+
+	// 권한은 일반 요청과 같이 행마다 검증되며, 거부된 행은 FulFill 이 멈추고 벌크 메서드의 값만 채워집니다.
+	assert.Nil(t, n.exportChunks(&b, writer, 2, fetch))
+	assert.Equal(t, [][]interface{}{{nil, 0, 2}, {int64(2), 2, 2}, {int64(4), 4, 2}}, calls)
+	assert.Equal(t, "commentCount,id,summary,text,userId,_error\n"+
+		"10,1,#1,text 1,1,\n"+
+		"20,,,,,No permission to read `userId`.\n"+
+		"30,3,#3,text 3,1,\n"+
+		"40,,,,,No permission to read `userId`.\n"+
+		"50,5,#5,text 5,1,\n", b.String())
+
+	// `_limit`에 도달하면 마지막 묶음의 크기를 줄이고 더 읽지 않습니다.
+	b.Reset()
+	calls = nil
+	n = newNode(map[string]interface{}{LIMIT: float64(3)})
+	writer, _ = newExportWriter(&b, NDJSON, nil)
+
+	assert.Nil(t, n.exportChunks(&b, writer, 2, fetch))
+	assert.Equal(t, [][]interface{}{{nil, 0, 2}, {int64(2), 2, 1}}, calls)
+	assert.Equal(t, 3, bytes.Count(b.Bytes(), []byte("\n")))
+
+	// 읽기에 실패하면 그대로 반환합니다.
+	n = newNode(map[string]interface{}{})
+	writer, _ = newExportWriter(&b, NDJSON, nil)
+	err := n.exportChunks(&b, writer, 2, func(interface{}, int, int) (interface{}, error) {
+		return nil, fmt.Errorf("Lost connection.")
+	})
+	assert.EqualError(t, err, "Lost connection.")
+}
@@ -0,0 +1,140 @@
+package request
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"reflect"
+	"strings"
+)
+
+// ------------------------------
+// Full-text Search
+// ------------------------------
+
+// `_where`의 `_match` 키로 여러 컬럼에 대한 전문 검색 조건을 생성합니다.
+// ex) _match: { columns: ["title", "body"], query: "octopus", mode: "boolean" }
+func parseMatchGroup(source map[string]interface{}, table *core.Table, schema *core.Schema) Condition {
+	query, mode := parseMatch(source)
+
+	return Condition{
+		Query: matchExpression(schema, table, matchColumns(source, table, schema), mode),
+		Args:  []interface{}{query},
+	}
+}
+
+// `_order`의 `_match` 키로 전문 검색의 관련도 점수에 의한 정렬을 생성합니다. 기본 정렬 방향은 DESC 입니다.
+func parseRelevance(source map[string]interface{}, table *core.Table, schema *core.Schema) Condition {
+	query, mode := parseMatch(source)
+	to := DESC
+
+	if v, exist := source["to"]; exist && v != nil {
+		to = parseDirection(MATCH_GROUP, v)
+	}
+
+	return Condition{
+		Query: fmt.Sprintf("%v %v", relevanceExpression(schema, table, matchColumns(source, table, schema), mode), to),
+		Args:  []interface{}{query},
+	}
+}
+
+// 검색어와 검색 방식을 해석합니다. 문자열 또는 { query, mode } 형태의 값을 허용합니다.
+func parseMatch(raw interface{}) (query interface{}, mode string) {
+	mode = NATURAL_MODE
+
+	if !core.IsKindOf(raw, reflect.Map) {
+		return raw, mode
+	}
+
+	m := core.ParseMap(raw)
+	query = m["query"]
+
+	if v, exist := m["mode"]; exist && v != nil {
+		mode = strings.ToLower(fmt.Sprintf("%v", v))
+	}
+
+	if mode != NATURAL_MODE && mode != BOOLEAN_MODE {
+		panic(newRequestError(400, "`%v` is not support match mode.", mode))
+	}
+
+	if query == nil {
+		panic(newRequestError(400, "The query of `%v` is required.", MATCH))
+	}
+
+	return
+}
+
+func matchColumns(source map[string]interface{}, table *core.Table, schema *core.Schema) (names []string) {
+	rawColumns, exist := source["columns"]
+
+	if !exist || !core.IsKindOf(rawColumns, reflect.Slice) {
+		panic(newRequestError(400, "The columns of `%v` must be a list.", MATCH_GROUP))
+	}
+
+	columns := reflect.ValueOf(rawColumns)
+	for i := 0; i < columns.Len(); i++ {
+		name := fmt.Sprintf("%v", columns.Index(i).Interface())
+		names = append(names, schema.MustColumn(table.Name, name).Name)
+	}
+
+	return
+}
+
+// FULLTEXT 인덱스가 존재하는 컬럼들에 대해서만 전문 검색 식을 생성합니다.
+func matchExpression(schema *core.Schema, table *core.Table, columnNames []string, mode string) string {
+	columns := fullTextColumns(schema, table, columnNames)
+
+	switch schema.Adapter {
+	case "", "mysql":
+		return fmt.Sprintf("MATCH(%v) AGAINST(? IN %v MODE)", strings.Join(columns, ", "), againstMode(mode))
+	case "postgres":
+		return fmt.Sprintf("to_tsvector(concat_ws(' ', %v)) @@ %v(?)", strings.Join(columns, ", "), tsQuery(mode))
+	}
+
+	panic(fmt.Errorf("`%v` adapter does not support full-text search.", schema.Adapter))
+}
+
+// 전문 검색의 관련도 점수를 반환하는 식을 생성합니다.
+func relevanceExpression(schema *core.Schema, table *core.Table, columnNames []string, mode string) string {
+	columns := fullTextColumns(schema, table, columnNames)
+
+	switch schema.Adapter {
+	case "", "mysql":
+		return fmt.Sprintf("MATCH(%v) AGAINST(? IN %v MODE)", strings.Join(columns, ", "), againstMode(mode))
+	case "postgres":
+		return fmt.Sprintf("ts_rank(to_tsvector(concat_ws(' ', %v)), %v(?))", strings.Join(columns, ", "), tsQuery(mode))
+	}
+
+	panic(fmt.Errorf("`%v` adapter does not support full-text search.", schema.Adapter))
+}
+
+func fullTextColumns(schema *core.Schema, table *core.Table, columnNames []string) (columns []string) {
+	if !table.HasFullText(columnNames...) {
+		panic(fmt.Errorf("`%v` table does not have a FULLTEXT index on (%v).", table.Name, strings.Join(columnNames, ", ")))
+	}
+
+	for _, name := range columnNames {
+		if schema.Adapter == "postgres" {
+			columns = append(columns, fmt.Sprintf("\"%v\".\"%v\"", table.Name, name))
+		} else {
+			columns = append(columns, fmt.Sprintf("`%v`.`%v`", table.Name, name))
+		}
+	}
+
+	return
+}
+
+func againstMode(mode string) string {
+	if mode == BOOLEAN_MODE {
+		return "BOOLEAN"
+	}
+
+	return "NATURAL LANGUAGE"
+}
+
+func tsQuery(mode string) string {
+	if mode == BOOLEAN_MODE {
+		return "to_tsquery"
+	}
+
+	return "plainto_tsquery"
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"github.com/finwhale/octopus/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseQuery_Match(t *testing.T) {
+	schema := core.GetSchema(false)
+	n := &Node{Name: "articleList", Type: "Article"}
+
+	conditions, _ := parseQuery(map[string]interface{}{
+		"text": map[string]interface{}{MATCH: "octopus"},
+	}, n, schema)
+	assert.Contains(t, conditions, Condition{
+		Query: "MATCH(`article`.`text`) AGAINST(? IN NATURAL LANGUAGE MODE)",
+		Args:  []interface{}{"octopus"},
+	})
+
+	conditions, _ = parseQuery(map[string]interface{}{
+		MATCH_GROUP: map[string]interface{}{"columns": []interface{}{"text"}, "query": "+octopus", "mode": "boolean"},
+	}, n, schema)
+	assert.Contains(t, conditions, Condition{
+		Query: "MATCH(`article`.`text`) AGAINST(? IN BOOLEAN MODE)",
+		Args:  []interface{}{"+octopus"},
+	})
+}
+
+func TestParseQuery_Match_WithoutIndex(t *testing.T) {
+	schema := core.GetSchema(false)
+	n := &Node{Name: "commentList", Type: "Comment"}
+
+	assert.Panics(t, func() {
+		parseQuery(map[string]interface{}{
+			"text": map[string]interface{}{MATCH: "octopus"},
+		}, n, schema)
+	})
+}
+
+func TestParseRelevance(t *testing.T) {
+	schema := core.GetSchema(false)
+	table := schema.MustTable("article")
+
+	relevance := parseRelevance(map[string]interface{}{"columns": []interface{}{"text"}, "query": "octopus"}, table, schema)
+	assert.Equal(t, relevance, Condition{
+		Query: "MATCH(`article`.`text`) AGAINST(? IN NATURAL LANGUAGE MODE) DESC",
+		Args:  []interface{}{"octopus"},
+	})
+}
+
+func TestParseMatch(t *testing.T) {
+	query, mode := parseMatch("octopus")
+	assert.Equal(t, query, "octopus")
+	assert.Equal(t, mode, NATURAL_MODE)
+
+	query, mode = parseMatch(map[string]interface{}{"query": "+octopus -squid", "mode": "BOOLEAN"})
+	assert.Equal(t, query, "+octopus -squid")
+	assert.Equal(t, mode, BOOLEAN_MODE)
+
+	assert.Panics(t, func() { parseMatch(map[string]interface{}{"query": "octopus", "mode": "fuzzy"}) })
+	assert.Panics(t, func() { parseMatch(map[string]interface{}{"mode": "boolean"}) })
+}
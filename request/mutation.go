@@ -0,0 +1,439 @@
+package request
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/jinzhu/gorm"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// 뮤테이션 중 발생한 검증 및 권한 오류들입니다. 하나라도 존재하면 트랜잭션은 롤백됩니다.
+	MutationError struct {
+		Errors []map[string]interface{}
+	}
+
+	// 요청의 인자가 잘못되었거나 허용되지 않아 실행할 수 없는 경우의 오류입니다. `_error`에 Code 를 그대로 담습니다.
+	RequestError struct {
+		Code    int
+		Message string
+	}
+)
+
+func (e *MutationError) Error() string {
+	var messages []string
+
+	for _, err := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%v", err["message"]))
+	}
+
+	return strings.Join(messages, " ")
+}
+
+func newRequestError(code int, format string, args ...interface{}) *RequestError {
+	return &RequestError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *RequestError) Error() string {
+	return e.Message
+}
+
+// ------------------------------
+// Mutation
+// ------------------------------
+
+// 노드의 이름에 따라 커스텀 뮤테이션 또는 생성, 수정, 삭제 뮤테이션을 실행합니다.
+// 요청에 트랜잭션이 열려 있다면 모든 쿼리는 해당 트랜잭션 안에서 실행됩니다.
+func (n *Node) Mutate() (*Result, error) {
+	// request.Mutation 에 노드의 이름과 동일한 메서드가 있다면 우선적으로 호출합니다.
+	if Mutation != nil {
+		method := reflect.ValueOf(Mutation).MethodByName(core.Classify(n.Name))
+
+		if method.IsValid() {
+			return n.callMutation(method)
+		}
+	}
+
+	if IsAuditModel(n.Type) {
+		return nil, newRequestError(400, "`%v` is read-only.", n.Type)
+	}
+
+	switch {
+	case strings.HasPrefix(n.Name, CREATE):
+		return n.create()
+	case strings.HasPrefix(n.Name, UPDATE):
+		return n.update()
+	case strings.HasPrefix(n.Name, DELETE):
+		return n.delete()
+	case strings.HasPrefix(n.Name, RESTORE):
+		return n.restore()
+	}
+
+	return nil, newRequestError(404, "`%v` is not support mutation.", n.Name)
+}
+
+// 커스텀 뮤테이션 메서드를 호출합니다. 메서드는 `func(*request.Node) (interface{}, error)` 형태여야 하며
+// `n.DB()`를 사용하면 요청과 동일한 트랜잭션에 참여합니다.
+func (n *Node) callMutation(method reflect.Value) (*Result, error) {
+	called := method.Call([]reflect.Value{reflect.ValueOf(n)})
+
+	if len(called) != 2 {
+		panic(fmt.Errorf("`%v` mutation must return (interface{}, error).", core.Classify(n.Name)))
+	}
+
+	if err, ok := called[1].Interface().(error); ok && err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Data: called[0].Interface(),
+	}, nil
+}
+
+func (n *Node) create() (*Result, error) {
+	schema := core.GetSchema(false)
+	table := schema.MustTable(n.Type)
+	primary, err := schema.GetPrimary(table.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := n.values(schema, table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stampTimestamps(table, values, true)
+	stampVersion(table, values)
+
+	if err = n.stampTenant(table, values, true); err != nil {
+		return nil, err
+	}
+
+	if err = n.validateWrite(camelCaseKeys(values)); err != nil {
+		return nil, err
+	}
+
+	columns, args := sortedValues(values)
+	var quoted, placeholders []string
+
+	for _, column := range columns {
+		quoted = append(quoted, fmt.Sprintf("`%v`", column))
+		placeholders = append(placeholders, "?")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO `%v` (%v) VALUES (%v)",
+		table.Name,
+		strings.Join(quoted, ", "),
+		strings.Join(placeholders, ", "),
+	)
+
+	if err = n.DB().Exec(query, args...).Error; err != nil {
+		return nil, err
+	}
+
+	id, exist := values[primary]
+
+	if !exist {
+		var lastId int64
+		if err = n.DB().Raw("SELECT LAST_INSERT_ID()").Row().Scan(&lastId); err != nil {
+			return nil, err
+		}
+
+		id = lastId
+	}
+
+	n.audit(table, id, nil, n.load(table, primary, id))
+
+	return n.resolve(table, primary, id), nil
+}
+
+func (n *Node) update() (*Result, error) {
+	schema := core.GetSchema(false)
+	table := schema.MustTable(n.Type)
+	primary, id, err := n.primary(schema, table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := n.find(table, primary, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = n.validateWrite(before); err != nil {
+		return nil, err
+	}
+
+	values, err := n.values(schema, table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	delete(values, primary)
+	stampTimestamps(table, values, false)
+	n.stampTenant(table, values, false)
+
+	var version interface{}
+	if table.Version != "" {
+		if version, err = n.lockVersion(table, values); err != nil {
+			return nil, err
+		}
+	}
+
+	columns, args := sortedValues(values)
+
+	if len(columns) > 0 || table.Version != "" {
+		var sets []string
+
+		for _, column := range columns {
+			sets = append(sets, fmt.Sprintf("`%v` = ?", column))
+		}
+
+		where := fmt.Sprintf("`%v` = ?", primary)
+		args = append(args, id)
+
+		// 버전 컬럼이 있다면 클라이언트가 읽은 버전과 같은 경우에만 수정하고 버전을 올립니다.
+		if table.Version != "" {
+			sets = append(sets, fmt.Sprintf("`%[1]v` = `%[1]v` + 1", table.Version))
+			where += fmt.Sprintf(" AND `%v` = ?", table.Version)
+			args = append(args, version)
+		}
+
+		query := fmt.Sprintf("UPDATE `%v` SET %v WHERE %v", table.Name, strings.Join(sets, ", "), where)
+		scope := n.DB().Exec(query, args...)
+
+		if scope.Error != nil {
+			return nil, scope.Error
+		}
+
+		if table.Version != "" && scope.RowsAffected == 0 {
+			return nil, n.conflict(table, primary, id, version)
+		}
+	}
+
+	n.audit(table, id, before, n.load(table, primary, id))
+
+	return n.resolve(table, primary, id), nil
+}
+
+func (n *Node) delete() (*Result, error) {
+	schema := core.GetSchema(false)
+	table := schema.MustTable(n.Type)
+	primary, id, err := n.primary(schema, table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := n.find(table, primary, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = n.validateWrite(before); err != nil {
+		return nil, err
+	}
+
+	// 삭제된 이후에는 조회할 수 없으므로 결과를 먼저 만들어둡니다.
+	result := n.resolve(table, primary, id)
+
+	if table.SoftDelete != "" {
+		err = n.softDelete(table, primary, id)
+	} else {
+		query := fmt.Sprintf("DELETE FROM `%v` WHERE `%v` = ?", table.Name, primary)
+		err = n.DB().Exec(query, id).Error
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	n.audit(table, id, before, n.load(table, primary, id))
+
+	return result, nil
+}
+
+// 변경된 레코드를 노드가 요청한 필드들로 채워 반환합니다.
+func (n *Node) resolve(table *core.Table, primary string, id interface{}) *Result {
+	db, data := n.Fetch(false, func(db *gorm.DB) *gorm.DB {
+		return db.Where(fmt.Sprintf("`%v`.`%v` = ?", table.Name, primary), id)
+	})
+
+	return &Result{
+		DB:   db,
+		Data: data,
+	}
+}
+
+// 기본키로 변경 전의 레코드를 불러옵니다. 복원은 삭제된 레코드를, 그 외에는 삭제되지 않은 레코드를 대상으로 합니다.
+// 다른 테넌트의 레코드는 존재하지 않는 것으로 취급하며, 이후의 수정과 삭제는 같은 트랜잭션에서 이 확인을 거친 레코드만 대상으로 합니다.
+func (n *Node) find(table *core.Table, primary string, id interface{}) (interface{}, error) {
+	tenant, err := n.tenant(table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	model := New(n.Type, false)
+	db := n.DB().Where(fmt.Sprintf("`%v`.`%v` = ?", table.Name, primary), id)
+
+	if table.Tenant != "" {
+		db = db.Where(fmt.Sprintf("`%v`.`%v` = ?", table.Name, table.Tenant), tenant)
+	}
+
+	if table.SoftDelete != "" {
+		if n.action() == RESTORE {
+			db = db.Where(fmt.Sprintf("`%v`.`%v` IS NOT NULL", table.Name, table.SoftDelete))
+		} else {
+			db = db.Where(fmt.Sprintf("`%v`.`%v` IS NULL", table.Name, table.SoftDelete))
+		}
+	}
+
+	scope := db.First(model)
+
+	if scope.RecordNotFound() {
+		return nil, &MutationError{
+			Errors: []map[string]interface{}{
+				{KEY: n.Name, "code": 404, "message": fmt.Sprintf("`%v` of `%v` does not exist.", id, n.Type)},
+			},
+		}
+	}
+
+	return model, scope.Error
+}
+
+// 수정 및 삭제 뮤테이션의 대상이 되는 기본키를 인자에서 찾습니다.
+func (n *Node) primary(schema *core.Schema, table *core.Table) (primary string, id interface{}, err error) {
+	primary, err = schema.GetPrimary(table.Name)
+
+	if err != nil {
+		return
+	}
+
+	id, exist := n.Args[core.CamelCase(primary)]
+
+	if !exist || id == nil {
+		err = newRequestError(400, "`%v` is required to %v `%v`.", core.CamelCase(primary), n.action(), n.Type)
+	}
+
+	return
+}
+
+// 인자로 전달된 값들을 컬럼 이름을 키로 하는 맵으로 변환합니다.
+func (n *Node) values(schema *core.Schema, table *core.Table) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	for name, value := range n.Args {
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+
+		column := schema.GetColumn(table.Name, name)
+
+		if column == nil {
+			return nil, newRequestError(400, "`%v` column does not exist in `%v` table.", name, table.Name)
+		}
+
+		values[column.Name] = value
+	}
+
+	return values, nil
+}
+
+// 쓰기 권한을 검증하고 실패한 경우 모든 오류를 담은 MutationError 를 반환합니다.
+func (n *Node) validateWrite(record interface{}) error {
+	defer n.observeCall(AUTHORITY)()
+
+	validatorMap, _ := GetAuthority(false).AnalyzeWrite(n)
+	var errors []map[string]interface{}
+
+	for name, validators := range validatorMap {
+		for _, validator := range validators {
+			statusCode, errorMessage := validator.ExecWrite(n.Request, name, record)
+
+			if errorMessage == "" {
+				continue
+			}
+
+			errors = append(errors, map[string]interface{}{KEY: name, "code": statusCode, "message": errorMessage})
+			n.observeDenial(name, statusCode)
+		}
+	}
+
+	if len(errors) > 0 {
+		return &MutationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (n *Node) action() string {
+	for _, action := range []string{CREATE, UPDATE, DELETE, RESTORE} {
+		if strings.HasPrefix(n.Name, action) {
+			return action
+		}
+	}
+
+	return n.Name
+}
+
+// ------------------------------
+// Utils
+// ------------------------------
+
+// 뮤테이션 오류를 `_error` 형태의 응답으로 변환합니다.
+func ErrorResult(key string, err error) *Result {
+	errors := []map[string]interface{}{}
+
+	if mutationError, ok := err.(*MutationError); ok {
+		errors = mutationError.Errors
+	} else if requestError, ok := err.(*RequestError); ok {
+		errors = append(errors, map[string]interface{}{KEY: key, "code": requestError.Code, "message": requestError.Message})
+	} else {
+		errors = append(errors, map[string]interface{}{KEY: key, "code": 500, "message": err.Error()})
+	}
+
+	return &Result{
+		Data: map[string]interface{}{
+			ERROR: map[string]interface{}{
+				DATA:  errors,
+				COUNT: len(errors),
+			},
+		},
+	}
+}
+
+// 컬럼 이름으로 정렬된 컬럼과 값의 목록을 반환합니다.
+func sortedValues(values map[string]interface{}) (columns []string, args []interface{}) {
+	for column := range values {
+		columns = append(columns, column)
+	}
+
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		args = append(args, values[column])
+	}
+
+	return
+}
+
+func camelCaseKeys(values map[string]interface{}) map[string]interface{} {
+	camelCased := map[string]interface{}{}
+
+	for k, v := range values {
+		camelCased[core.CamelCase(k)] = v
+	}
+
+	return camelCased
+}
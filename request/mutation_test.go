@@ -0,0 +1,66 @@
+package request
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNode_Mutate_NotSupport(t *testing.T) {
+	n := &Node{Name: "publishBook", Type: "Book"}
+
+	_, err := n.Mutate()
+	assert.EqualError(t, err, "`publishBook` is not support mutation.")
+}
+
+type testMutation struct{}
+
+func (testMutation) PublishBook(n *Node) (interface{}, error) {
+	return n.Args["id"], nil
+}
+
+func (testMutation) RejectBook(n *Node) (interface{}, error) {
+	return nil, fmt.Errorf("rejected")
+}
+
+func TestNode_Mutate_Custom(t *testing.T) {
+	Mutation = testMutation{}
+	defer func() { Mutation = nil }()
+
+	n := &Node{Name: "publishBook", Type: "Book", Args: map[string]interface{}{"id": 3}}
+	result, err := n.Mutate()
+	assert.Nil(t, err)
+	assert.Equal(t, result.Data, 3)
+
+	n = &Node{Name: "rejectBook", Type: "Book"}
+	_, err = n.Mutate()
+	assert.EqualError(t, err, "rejected")
+}
+
+func TestErrorResult(t *testing.T) {
+	result := ErrorResult("createBook", fmt.Errorf("foo"))
+	errorMap := result.Data.(map[string]interface{})[ERROR].(map[string]interface{})
+	assert.Equal(t, errorMap[COUNT], 1)
+	assert.Equal(t, errorMap[DATA].([]map[string]interface{})[0]["message"], "foo")
+
+	result = ErrorResult("createBook", &MutationError{
+		Errors: []map[string]interface{}{
+			{KEY: "title", "code": 401, "message": "bar"},
+			{KEY: "price", "code": 401, "message": "baz"},
+		},
+	})
+	errorMap = result.Data.(map[string]interface{})[ERROR].(map[string]interface{})
+	assert.Equal(t, errorMap[COUNT], 2)
+
+	result = ErrorResult("createBook", newRequestError(400, "`%v` column does not exist in `%v` table.", "foo", "book"))
+	errorMap = result.Data.(map[string]interface{})[ERROR].(map[string]interface{})
+	assert.Equal(t, []map[string]interface{}{
+		{KEY: "createBook", "code": 400, "message": "`foo` column does not exist in `book` table."},
+	}, errorMap[DATA])
+}
+
+func TestSortedValues(t *testing.T) {
+	columns, args := sortedValues(map[string]interface{}{"title": "foo", "price": 3, "author_id": 1})
+	assert.Equal(t, columns, []string{"author_id", "price", "title"})
+	assert.Equal(t, args, []interface{}{1, 3, "foo"})
+}
@@ -0,0 +1,154 @@
+package request
+
+import (
+	"github.com/finwhale/octopus/core"
+	"github.com/jinzhu/gorm"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// 요청을 처리하는 과정을 관찰합니다. 지표 수집, 로깅, 추적처럼 요청의 결과에 영향을 주지 않는 용도로 사용합니다.
+	Observer interface {
+		// 요청을 실행하기 시작할 때 호출되며, 반환된 함수는 요청이 끝났을 때 호출됩니다.
+		ObserveRequest(r *Request) func()
+		// 노드를 조회하기 시작할 때 호출되며, 반환된 함수는 조회가 끝났을 때 호출됩니다.
+		ObserveNode(n *Node) func()
+		// 커스텀 GetX, BulkX 메서드나 권한 검증을 실행하기 시작할 때 호출되며, 반환된 함수는 실행이 끝났을 때 호출됩니다.
+		ObserveCall(n *Node, name string) func()
+		// 노드를 조회하면서 실행된 SQL 마다 호출됩니다.
+		ObserveSQL(n *Node, sql string, vars []interface{}, duration time.Duration)
+		// 권한이 없어 필드나 뮤테이션이 거부된 경우 호출됩니다.
+		ObserveDenial(n *Node, key string, code int)
+		// 요청을 실행하는 중 패닉이 발생한 경우 호출됩니다.
+		ObservePanic(r *Request, recovered interface{})
+	}
+
+	// 로거를 연결한 데이터베이스를 찾는 키입니다.
+	observedKey struct {
+		node *Node
+		db   *gorm.DB
+	}
+
+	// 요청 또는 노드에서 실행되는 SQL 을 관찰자들에게 전달하는 gorm 로거입니다.
+	sqlLogger struct {
+		request *Request
+		node    *Node
+	}
+)
+
+var observers []Observer
+
+const AUTHORITY = "Authority"
+
+// 관찰자를 등록합니다. 서버를 시작하기 전에 호출해야 합니다.
+func AddObserver(o Observer) {
+	observers = append(observers, o)
+}
+
+func ObserveRequest(r *Request) func() {
+	var dones []func()
+
+	for _, o := range observers {
+		dones = append(dones, o.ObserveRequest(r))
+	}
+
+	return func() {
+		for i := len(dones) - 1; i >= 0; i-- {
+			dones[i]()
+		}
+	}
+}
+
+func ObservePanic(r *Request, recovered interface{}) {
+	for _, o := range observers {
+		o.ObservePanic(r, recovered)
+	}
+}
+
+func (n *Node) observe() func() {
+	var dones []func()
+
+	for _, o := range observers {
+		dones = append(dones, o.ObserveNode(n))
+	}
+
+	return func() {
+		for i := len(dones) - 1; i >= 0; i-- {
+			dones[i]()
+		}
+	}
+}
+
+func (n *Node) observeCall(name string) func() {
+	var dones []func()
+
+	for _, o := range observers {
+		dones = append(dones, o.ObserveCall(n, name))
+	}
+
+	return func() {
+		for i := len(dones) - 1; i >= 0; i-- {
+			dones[i]()
+		}
+	}
+}
+
+func (n *Node) observeDenial(key string, code int) {
+	for _, o := range observers {
+		o.ObserveDenial(n, key, code)
+	}
+}
+
+// 요청에서 실행한 SQL 의 개수를 반환합니다. 관찰자가 등록된 경우에만 집계됩니다.
+func (r *Request) SQLCount() int64 {
+	return atomic.LoadInt64(&r.sqlCount)
+}
+
+// 데이터베이스에서 실행되는 SQL 을 노드 단위로 관찰할 수 있도록 요청 전용의 로거를 연결합니다.
+// 로거를 연결한 데이터베이스는 노드와 원래의 데이터베이스(트랜잭션, 복제본 등)별로 한 번만 만들어 재사용합니다.
+func (r *Request) observe(n *Node, db *gorm.DB) *gorm.DB {
+	if len(observers) == 0 {
+		return db
+	}
+
+	key := observedKey{node: n, db: db}
+
+	if observed, ok := r.observedDBs.Load(key); ok {
+		return observed.(*gorm.DB)
+	}
+
+	observed := db.New()
+	observed.LogMode(true)
+	observed.SetLogger(&sqlLogger{request: r, node: n})
+
+	actual, _ := r.observedDBs.LoadOrStore(key, observed)
+
+	return actual.(*gorm.DB)
+}
+
+var defaultLogger = gorm.Logger{LogWriter: log.New(os.Stdout, "\r\n", 0)}
+
+func (l *sqlLogger) Print(values ...interface{}) {
+	// 설정에서 logmode 를 켠 경우에만 모든 SQL 을 출력하고, 그 외에는 gorm 과 동일하게 오류만 출력합니다.
+	if len(values) < 6 || values[0] != "sql" {
+		defaultLogger.Print(values...)
+		return
+	}
+
+	if core.IsLogMode() {
+		defaultLogger.Print(values...)
+	}
+
+	atomic.AddInt64(&l.request.sqlCount, 1)
+
+	duration, _ := values[2].(time.Duration)
+	sql, _ := values[3].(string)
+	vars, _ := values[4].([]interface{})
+
+	for _, o := range observers {
+		o.ObserveSQL(l.node, sql, vars, duration)
+	}
+}
@@ -0,0 +1,114 @@
+package request
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) ObserveRequest(r *Request) func() {
+	o.events = append(o.events, "request:"+r.Name)
+	return func() { o.events = append(o.events, "/request:"+r.Name) }
+}
+
+func (o *recordingObserver) ObserveNode(n *Node) func() {
+	o.events = append(o.events, "node:"+n.Name)
+	return func() { o.events = append(o.events, "/node:"+n.Name) }
+}
+
+func (o *recordingObserver) ObserveCall(n *Node, name string) func() {
+	o.events = append(o.events, "call:"+name)
+	return func() { o.events = append(o.events, "/call:"+name) }
+}
+
+func (o *recordingObserver) ObserveSQL(n *Node, sql string, _ []interface{}, _ time.Duration) {
+	o.events = append(o.events, "sql:"+sql)
+}
+
+func (o *recordingObserver) ObserveDenial(n *Node, key string, _ int) {
+	o.events = append(o.events, "denial:"+key)
+}
+
+func (o *recordingObserver) ObservePanic(r *Request, recovered interface{}) {
+	o.events = append(o.events, "panic:"+recovered.(string))
+}
+
+func TestObserver(t *testing.T) {
+	o := &recordingObserver{}
+	AddObserver(o)
+	defer func() { observers = nil }()
+
+	r := &Request{Name: "books", Node: &Node{Name: "books", Type: "Book"}}
+	r.SetUp()
+
+	done := ObserveRequest(r)
+	r.Node.observe()()
+	r.Node.observeCall("GetTitle")()
+	r.Node.observeDenial("price", 403)
+	(&sqlLogger{request: r, node: r.Node}).Print("sql", "", time.Millisecond, "SELECT 1", []interface{}{}, int64(1))
+	ObservePanic(r, "boom")
+	done()
+
+	assert.Equal(t, o.events, []string{
+		"request:books",
+		"node:books",
+		"/node:books",
+		"call:GetTitle",
+		"/call:GetTitle",
+		"denial:price",
+		"sql:SELECT 1",
+		"panic:boom",
+		"/request:books",
+	})
+	assert.Equal(t, r.SQLCount(), int64(1))
+}
+
+type observerDriver struct{}
+
+type observerConn struct{}
+
+func (observerDriver) Open(string) (driver.Conn, error) {
+	return observerConn{}, nil
+}
+
+func (observerConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("Not supported.")
+}
+
+func (observerConn) Close() error {
+	return nil
+}
+
+func (observerConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("Not supported.")
+}
+
+func TestRequest_Observe(t *testing.T) {
+	AddObserver(&recordingObserver{})
+	defer func() { observers = nil }()
+
+	sql.Register("octopus_observer", observerDriver{})
+	sqlDB, err := sql.Open("octopus_observer", "")
+	assert.Nil(t, err)
+
+	db, err := gorm.Open("mysql", sqlDB)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	r := &Request{Name: "books", Node: &Node{Name: "books", Type: "Book", Fields: map[string]*Node{"author": {Name: "author"}}}}
+	r.SetUp()
+
+	// 같은 노드와 데이터베이스라면 로거를 연결한 데이터베이스를 다시 만들지 않습니다.
+	observed := r.observe(r.Node, db)
+	assert.NotEqual(t, db, observed)
+	assert.True(t, observed == r.observe(r.Node, db))
+	assert.False(t, observed == r.observe(r.Node.Fields["author"], db))
+}
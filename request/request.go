@@ -7,7 +7,9 @@ import (
 	"github.com/jinzhu/gorm"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -19,6 +21,10 @@ const (
 	OR               = "_or"
 	AND              = "_and"
 	WHERE            = "_where"
+	NOT              = "_not"
+	NULLS            = "nulls"
+	FIRST            = "first"
+	LAST             = "last"
 	ORDER            = "_order"
 	COUNT            = "_count"
 	LIMIT            = "_limit"
@@ -37,6 +43,16 @@ const (
 	GREAT_THAN_EQUAL = "gte"
 	LIKE             = "like"
 	INSENSITIVE_LIKE = "ilike"
+	BETWEEN          = "between"
+	STARTS_WITH      = "startsWith"
+	ENDS_WITH        = "endsWith"
+	CONTAINS         = "contains"
+	REGEX            = "regex"
+	IS_EMPTY         = "isEmpty"
+	MATCH            = "match"
+	MATCH_GROUP      = "_match"
+	NATURAL_MODE     = "natural"
+	BOOLEAN_MODE     = "boolean"
 	ASC              = "ASC"
 	DESC             = "DESC"
 	SUM              = "SUM"
@@ -44,6 +60,16 @@ const (
 	DATETIME         = "DateTime"
 	FORMAT           = "format"
 	KEY              = "key"
+	CREATE           = "create"
+	UPDATE           = "update"
+	DELETE           = "delete"
+	RESTORE          = "restore"
+	WITH_DELETED     = "_withDeleted"
+	ONLY_DELETED     = "_onlyDeleted"
+	CONSISTENCY      = "_consistency"
+	STRONG           = "strong"
+	MUTATION         = "mutation"
+	REQUEST_ID       = "X-Request-Id"
 )
 
 type (
@@ -58,12 +84,19 @@ type (
 	}
 
 	Request struct {
-		Name      string      `json:"name"`
-		Operation string      `json:"operation"`
-		user      CurrentUser `json:"-"`
-		UserId    interface{} `json:"userId"`
-		Node      *Node       `json:"node"`
-		Header    http.Header `json:"-"`
+		Name             string       `json:"name"`
+		Operation        string       `json:"operation"`
+		user             CurrentUser  `json:"-"`
+		tx               *gorm.DB     `json:"-"` // 뮤테이션 요청에서 사용되는 트랜잭션
+		audits           []AuditEntry `json:"-"` // 트랜잭션이 커밋될 때 저장될 감사 기록들
+		committedAudits  []AuditEntry `json:"-"` // 트랜잭션이 커밋된 후에 파일에 저장될 감사 기록들
+		tenant           interface{}  `json:"-"` // 요청이 접근할 수 있는 테넌트
+		isTenantResolved bool         `json:"-"`
+		sqlCount         int64        `json:"-"` // 요청에서 실행한 SQL 의 개수
+		observedDBs      sync.Map     `json:"-"` // 노드와 데이터베이스별로 로거를 연결한 데이터베이스
+		UserId           interface{}  `json:"userId"`
+		Node             *Node        `json:"node"`
+		Header           http.Header  `json:"-"`
 	}
 
 	Node struct {
@@ -86,7 +119,7 @@ type (
 		Ors          [][]Condition          `json:"-"`
 		Ands         [][][]Condition        `json:"-"`
 		Wheres       []Condition            `json:"-"`
-		Orders       []string               `json:"-"`
+		Orders       []Condition            `json:"-"`
 		ValidatorMap map[string][]Validator `json:"-"`
 	}
 
@@ -117,17 +150,21 @@ func Connect(n *Node, r *Request) {
 	}
 }
 
+// 요청을 구분하는 ID 를 반환합니다. 서버가 `X-Request-Id` 헤더로 전달합니다.
+func (r *Request) Id() string {
+	if r.Header == nil {
+		return ""
+	}
+
+	return r.Header.Get(REQUEST_ID)
+}
+
 func (r *Request) GetUser() CurrentUser {
 	if r.user == nil {
-		if r.UserId == "" {
+		if r.UserId == nil || r.UserId == "" {
 			r.user = &AnonymousUser{}
 		} else {
-			userModelName := UserModelName
-
-			// 설정된 사용자 모델명이 없다면 "User"를 기본값으로 사용한다.
-			if userModelName == "" {
-				userModelName = DEFAULT_USER
-			}
+			userModelName := userModelName()
 
 			schema := core.GetSchema(false)
 			table := schema.MustTable(userModelName)
@@ -142,7 +179,7 @@ func (r *Request) GetUser() CurrentUser {
 
 			user := New(userModelName, false)
 			whereString := fmt.Sprintf("`%v`.`%v` = ?", table.Name, primary)
-			scope := core.GetDB().Model(user).Where(whereString, r.UserId).First(user)
+			scope := r.DB().Model(user).Where(whereString, r.UserId).First(user)
 
 			if scope.RowsAffected > 0 {
 				r.user = user.(CurrentUser)
@@ -155,12 +192,83 @@ func (r *Request) GetUser() CurrentUser {
 	return r.user
 }
 
+// 요청에서 사용할 데이터베이스를 반환합니다. 트랜잭션이 열려있다면 트랜잭션을 반환합니다.
+func (r *Request) DB() *gorm.DB {
+	return r.observe(r.Node, r.db())
+}
+
+func (r *Request) db() *gorm.DB {
+	if r.tx != nil {
+		return r.tx
+	}
+
+	// 뮤테이션과 강한 일관성을 요구하는 조회는 기본 데이터베이스를, 그 외의 조회는 읽기 전용 복제본을 사용합니다.
+	if r.Operation == MUTATION || r.IsStrong() {
+		return core.GetDB()
+	}
+
+	return core.GetReadDB()
+}
+
+// 최상위 노드에 `_consistency: strong` 인자가 있다면 복제 지연 없이 기본 데이터베이스에서 조회합니다.
+func (r *Request) IsStrong() bool {
+	return r.Node != nil && r.Node.Args[CONSISTENCY] == STRONG
+}
+
+// 요청 전체에서 공유하는 트랜잭션을 시작합니다.
+func (r *Request) Begin() *gorm.DB {
+	if r.tx != nil {
+		panic(fmt.Errorf("The transaction of `%v` request has already begun.", r.Name))
+	}
+
+	r.tx = core.GetDB().Begin()
+	core.Check(r.tx.Error)
+
+	return r.tx
+}
+
+// 트랜잭션을 커밋하고 요청에서 해제합니다.
+func (r *Request) Commit() error {
+	if r.tx == nil {
+		return nil
+	}
+
+	err := r.tx.Commit().Error
+	r.tx = nil
+
+	if err == nil {
+		err = r.writeCommittedAudits()
+	}
+
+	r.committedAudits = nil
+
+	return err
+}
+
+// 트랜잭션을 롤백하고 요청에서 해제합니다.
+func (r *Request) Rollback() error {
+	r.committedAudits = nil
+
+	if r.tx == nil {
+		return nil
+	}
+
+	err := r.tx.Rollback().Error
+	r.tx = nil
+
+	return err
+}
+
 // ------------------------------
 // Node
 // ------------------------------
 
 // 노드가 요청하는 데이터를 GraphQL 규격에 맞게 변형하여 최종 결과물을 만듭니다.
 func (n *Node) Result(handlers ...QueryHandler) *Result {
+	if err := n.validateDeleted(); err != nil {
+		return ErrorResult(n.Name, err)
+	}
+
 	n.Analyze(false)
 	db, data := n.Fetch(n.IsList || n.IsPlainList, handlers...)
 
@@ -183,6 +291,8 @@ func (n *Node) Result(handlers ...QueryHandler) *Result {
 
 // 노드가 요청한 데이터를 변형하지 않고 불러온다.
 func (n *Node) Fetch(isList bool, handlers ...QueryHandler) (*gorm.DB, interface{}) {
+	defer n.observe()()
+
 	n.Analyze(false)
 	db, model := n.Query(isList, handlers...)
 	fetchDB := db
@@ -227,11 +337,12 @@ func (n *Node) Query(isList bool, handlers ...QueryHandler) (db *gorm.DB, model
 		args = append(args, reflect.ValueOf(n))
 		db = method.Call(args)[0].Interface().(*gorm.DB)
 	} else {
-		db = core.GetDB().Model(returnModel)
+		db = n.DB().Model(returnModel)
 	}
 
 	_select, _ := n.selectString()
 	db = db.Select(_select)
+	db = n.scopeSoftDelete(db)
 
 	for _, join := range n.Joins {
 		originModel := Get(join.Origin)
@@ -245,6 +356,8 @@ func (n *Node) Query(isList bool, handlers ...QueryHandler) (db *gorm.DB, model
 		db = method.Call(values)[0].Interface().(*gorm.DB)
 	}
 
+	db = n.scopeTenant(db)
+
 	if len(n.Ors) > 0 {
 		var query string
 		var args []interface{}
@@ -313,7 +426,17 @@ func (n *Node) Query(isList bool, handlers ...QueryHandler) (db *gorm.DB, model
 	}
 
 	for _, order := range n.Orders {
-		db = db.Order(order)
+		if len(order.Args) > 0 {
+			db = db.Order(gorm.Expr(order.Query, order.Args...))
+		} else {
+			db = db.Order(order.Query)
+		}
+	}
+
+	if isList {
+		if tiebreaker, ok := n.tiebreaker(); ok {
+			db = db.Order(tiebreaker.Query)
+		}
 	}
 
 	if isList {
@@ -372,7 +495,9 @@ func (n *Node) Bulk(db *gorm.DB, models interface{}, data interface{}) interface
 		var args []reflect.Value
 		args = append(args, reflect.ValueOf(n.Find(name)), reflect.ValueOf(extracted))
 		method := reflect.ValueOf(model).MethodByName(core.EncapCase(BULK, name))
+		done := n.observeCall(core.EncapCase(BULK, name))
 		called := method.Call(args)
+		done()
 
 		if called[1].IsValid() && called[1].Interface() != nil {
 			panic(called[1])
@@ -414,36 +539,23 @@ func (n *Node) Analyze(force bool) (customs []string, persists []string, bulks [
 	}
 
 	if order, ok := n.Args[ORDER]; ok {
-		iterate(order, n.Type, func(isObject bool, parentName string, name string, source map[string]interface{}) {
-			table := schema.GetTable(parentName)
+		// 배열 형태의 정렬은 배열의 순서대로 우선순위를 가집니다.
+		orderList := []interface{}{order}
 
-			if table == nil {
-				return
-			}
-
-			if isObject {
-				childTable := schema.GetTable(name)
-
-				if childTable == nil {
-					return
-				}
-
-				cJoins = append(cJoins, Join{
-					Origin: table.Name,
-					Target: childTable.Name,
-				})
-
-				return
-			}
-
-			column := schema.GetColumn(table.Name, name)
+		if core.IsKindOf(order, reflect.Slice) {
+			orderListValue := reflect.ValueOf(order)
+			orderList = []interface{}{}
 
-			if column == nil {
-				return
+			for i := 0; i < orderListValue.Len(); i++ {
+				orderList = append(orderList, orderListValue.Index(i).Interface())
 			}
+		}
 
-			n.Orders = append(n.Orders, fmt.Sprintf("`%v`.`%v` %v", table.Name, column.Name, source["to"]))
-		})
+		for _, o := range orderList {
+			orders, joins := parseOrder(o, n, schema)
+			n.Orders = append(n.Orders, orders...)
+			cJoins = append(cJoins, joins...)
+		}
 	}
 
 	if orList, ok := n.Args[OR]; ok {
@@ -544,6 +656,24 @@ Loop:
 	return
 }
 
+// 노드가 포함된 요청의 데이터베이스를 반환합니다.
+func (n *Node) DB() *gorm.DB {
+	if n.Request != nil {
+		return n.Request.observe(n, n.Request.db())
+	}
+
+	return core.GetDB()
+}
+
+// 최상위 노드부터 현재 노드까지의 이름을 점으로 연결한 경로를 반환합니다.
+func (n *Node) Path() string {
+	if n.Parent == nil {
+		return n.Name
+	}
+
+	return n.Parent.Path() + "." + n.Name
+}
+
 // 노드의 필드를 검색합니다.
 func (n *Node) Find(candidate string) *Node {
 	name := core.CamelCase(candidate)
@@ -660,6 +790,8 @@ func (n *Node) selectString() (string, bool) {
 
 func (n *Node) Validate(candidate string, model interface{}) (errors []map[string]interface{}) {
 	if validators, exist := n.ValidatorMap[candidate]; exist {
+		defer n.observeCall(AUTHORITY)()
+
 		for _, validator := range validators {
 			statusCode, errorMessage := validator.Exec(n.Find(candidate), model)
 
@@ -669,6 +801,7 @@ func (n *Node) Validate(candidate string, model interface{}) (errors []map[strin
 
 			err := map[string]interface{}{"key": candidate, "code": statusCode, "message": errorMessage}
 			errors = append(errors, err)
+			n.observeDenial(candidate, statusCode)
 		}
 	}
 
@@ -730,7 +863,9 @@ func doFulFill(n *Node, model interface{}) map[string]interface{} {
 		method := value.MethodByName(core.EncapCase(GET, custom))
 		args := []reflect.Value{}
 		args = append(args, reflect.ValueOf(n.Find(custom)))
+		done := n.observeCall(core.EncapCase(GET, custom))
 		fulfilled[custom] = method.Call(args)[0].Interface()
+		done()
 	}
 
 	errorMap := map[string]interface{}{}
@@ -745,6 +880,160 @@ func doFulFill(n *Node, model interface{}) map[string]interface{} {
 	return fulfilled
 }
 
+// 정렬 맵을 순회하며 정렬 조건과 조인을 생성합니다. 컬럼의 값으로 `DESC`와 같은 방향만 지정하거나
+// { to: DESC, nulls: last } 와 같이 NULL 값의 위치를 함께 지정할 수 있습니다.
+func parseOrder(c interface{}, n *Node, schema *core.Schema) (orders []Condition, joins []Join) {
+	iterate(normalizeOrder(c), n.Type, func(isObject bool, parentName string, name string, source map[string]interface{}) {
+		table := schema.GetTable(parentName)
+
+		if table == nil {
+			return
+		}
+
+		if name == MATCH_GROUP {
+			orders = append(orders, parseRelevance(source, table, schema))
+			return
+		}
+
+		if isObject {
+			childTable := schema.GetTable(name)
+
+			if childTable == nil {
+				return
+			}
+
+			joins = append(joins, Join{
+				Origin: table.Name,
+				Target: childTable.Name,
+			})
+
+			return
+		}
+
+		column := schema.GetColumn(table.Name, name)
+
+		if column == nil {
+			return
+		}
+
+		columnName := fmt.Sprintf("`%v`.`%v`", table.Name, column.Name)
+		to := parseDirection(columnName, source["to"])
+
+		if nulls, exist := source[NULLS]; exist && nulls != nil {
+			switch strings.ToLower(fmt.Sprintf("%v", nulls)) {
+			case FIRST:
+				orders = append(orders, Condition{Query: fmt.Sprintf("%v IS NULL DESC", columnName)})
+			case LAST:
+				orders = append(orders, Condition{Query: fmt.Sprintf("%v IS NULL ASC", columnName)})
+			default:
+				panic(newRequestError(400, "`%v` of %v must be `first` or `last`.", NULLS, columnName))
+			}
+		}
+
+		orders = append(orders, Condition{Query: fmt.Sprintf("%v %v", columnName, to)})
+	})
+
+	return
+}
+
+// `{ createdAt: DESC }`처럼 방향만 지정한 값을 `{ createdAt: { to: DESC } }` 형태로 변환합니다.
+func normalizeOrder(c interface{}) interface{} {
+	if !core.IsKindOf(c, reflect.Map) {
+		return map[string]interface{}{"to": c}
+	}
+
+	normalized := map[string]interface{}{}
+
+	for k, v := range core.ParseMap(c) {
+		if k == "_object" || k == MATCH_GROUP || k == "to" || k == NULLS || k == "func" {
+			normalized[k] = v
+		} else {
+			normalized[k] = normalizeOrder(v)
+		}
+	}
+
+	return normalized
+}
+
+// 정렬 방향은 ASC 또는 DESC 만 허용합니다.
+func parseDirection(name string, raw interface{}) string {
+	if raw == nil {
+		return ASC
+	}
+
+	to := strings.ToUpper(fmt.Sprintf("%v", raw))
+
+	if to != ASC && to != DESC {
+		panic(newRequestError(400, "`%v` is not support order direction. (%v)", raw, name))
+	}
+
+	return to
+}
+
+// 페이지네이션 결과가 항상 동일하도록 기본키를 마지막 정렬 기준으로 추가합니다.
+func (n *Node) tiebreaker() (Condition, bool) {
+	schema := core.GetSchema(false)
+	table := schema.GetTable(n.Type)
+	primary, err := schema.GetPrimary(n.Type)
+
+	if table == nil || err != nil {
+		return Condition{}, false
+	}
+
+	columnName := fmt.Sprintf("`%v`.`%v`", table.Name, primary)
+
+	for _, order := range n.Orders {
+		if order.Query == columnName+" "+ASC || order.Query == columnName+" "+DESC {
+			return Condition{}, false
+		}
+	}
+
+	return Condition{Query: columnName + " " + ASC}, true
+}
+
+// 조건들을 AND 로 묶어 부정합니다. NULL 과의 비교로 결과가 UNKNOWN 인 경우에도
+// 조건을 만족하지 않은 것으로 보아 부정된 결과에 포함되도록 COALESCE 로 감쌉니다.
+func negate(conditions []Condition) (Condition, bool) {
+	var queries []string
+	var args []interface{}
+
+	for _, condition := range conditions {
+		if condition.Query == "" {
+			continue
+		}
+
+		queries = append(queries, "("+condition.Query+")")
+		args = append(args, condition.Args...)
+	}
+
+	if len(queries) == 0 {
+		return Condition{}, false
+	}
+
+	return Condition{
+		Query: fmt.Sprintf("NOT (COALESCE(%v, FALSE))", strings.Join(queries, " AND ")),
+		Args:  args,
+	}, true
+}
+
+// LIKE 패턴에서 특수한 의미를 가지는 `\`, `%`, `_` 문자를 이스케이프합니다.
+func escapeLike(val interface{}) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+	return replacer.Replace(fmt.Sprintf("%v", val))
+}
+
+// between 연산자의 값은 [시작, 끝] 형태의 배열이어야 합니다.
+func parseRange(name string, val interface{}) (from interface{}, to interface{}) {
+	if !core.IsKindOf(val, reflect.Slice) || reflect.ValueOf(val).Len() != 2 {
+		panic(newRequestError(400, "`%v` of %v must be a list of two values.", BETWEEN, name))
+	}
+
+	vs := reflect.ValueOf(val)
+
+	return vs.Index(0).Interface(), vs.Index(1).Interface()
+}
+
 func iterate(
 	c interface{},
 	parentName string,
@@ -754,10 +1043,16 @@ func iterate(
 	objectKey := reflect.ValueOf("_object")
 
 	if condition.Kind() != reflect.Map {
-		panic(fmt.Errorf("Only the map type can be used."))
+		panic(newRequestError(400, "Only the map type can be used."))
 	}
 
-	for _, nameValue := range condition.MapKeys() {
+	// 맵의 순서는 보장되지 않으므로 항상 동일한 쿼리가 생성되도록 키를 정렬합니다.
+	nameValues := condition.MapKeys()
+	sort.Slice(nameValues, func(i, j int) bool {
+		return nameValues[i].Interface().(string) < nameValues[j].Interface().(string)
+	})
+
+	for _, nameValue := range nameValues {
 		name := nameValue.Interface().(string)
 		sourceValue := reflect.ValueOf(condition.MapIndex(nameValue).Interface())
 
@@ -771,7 +1066,8 @@ func iterate(
 			source[sourceName] = sourceValue.MapIndex(sourceNameValue).Interface()
 		}
 
-		if sourceValue.MapIndex(objectKey).IsValid() {
+		// `_not`은 현재 테이블의 조건들을 묶은 것이므로 하위 객체로 순회하지 않습니다.
+		if name != NOT && sourceValue.MapIndex(objectKey).IsValid() {
 			creator(true, parentName, name, source)
 			iterate(sourceValue.Interface(), name, creator)
 
@@ -783,7 +1079,12 @@ func iterate(
 }
 
 func parseQuery(c interface{}, n *Node, schema *core.Schema) (conditions []Condition, joins []Join) {
-	iterate(c, n.Type, func(isObject bool, parentName string, name string, source map[string]interface{}) {
+	return parseQueryFrom(c, n.Type, schema)
+}
+
+// parentName 테이블을 기준으로 조건 맵을 순회하며 조건과 조인을 생성합니다.
+func parseQueryFrom(c interface{}, parentName string, schema *core.Schema) (conditions []Condition, joins []Join) {
+	iterate(c, parentName, func(isObject bool, parentName string, name string, source map[string]interface{}) {
 		table := schema.GetTable(parentName)
 
 		if table == nil {
@@ -804,6 +1105,21 @@ func parseQuery(c interface{}, n *Node, schema *core.Schema) (conditions []Condi
 			return
 		}
 
+		if name == MATCH_GROUP {
+			conditions = append(conditions, parseMatchGroup(source, table, schema))
+			return
+		}
+
+		if name == NOT {
+			notConditions, notJoins := parseQueryFrom(source, parentName, schema)
+			joins = append(joins, notJoins...)
+
+			if negated, ok := negate(notConditions); ok {
+				conditions = append(conditions, negated)
+			}
+			return
+		}
+
 		var queries []string
 		var args []interface{}
 		column := schema.GetColumn(table.Name, name)
@@ -813,49 +1129,77 @@ func parseQuery(c interface{}, n *Node, schema *core.Schema) (conditions []Condi
 		}
 
 		for opName, val := range source {
-			if opName != NIL {
-				args = append(args, val)
-			}
+			name := fmt.Sprintf("`%v`.`%v`", table.Name, column.Name)
 
 			switch opName {
 			case EQUAL:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` = ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v = ?", name))
+				args = append(args, val)
 			case NOT_EQUAL:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` != ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v != ?", name))
+				args = append(args, val)
 			case IN:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` IN (?)", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v IN (?)", name))
+				args = append(args, val)
 			case NOT_IN:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` NOT IN (?)", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v NOT IN (?)", name))
+				args = append(args, val)
 			case NIL:
 				if val.(bool) {
-					queries = append(queries, fmt.Sprintf("`%v`.`%v` IS NULL", table.Name, column.Name))
+					queries = append(queries, fmt.Sprintf("%v IS NULL", name))
 				} else {
-					queries = append(queries, fmt.Sprintf("`%v`.`%v` IS NOT NULL", table.Name, column.Name))
+					queries = append(queries, fmt.Sprintf("%v IS NOT NULL", name))
 				}
 			case LESS_THAN:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` < ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v < ?", name))
+				args = append(args, val)
 			case LESS_THAN_EQUAL:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` <= ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v <= ?", name))
+				args = append(args, val)
 			case GREAT_THAN:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` > ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v > ?", name))
+				args = append(args, val)
 			case GREAT_THAN_EQUAL:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` >= ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v >= ?", name))
+				args = append(args, val)
+			case BETWEEN:
+				from, to := parseRange(name, val)
+				queries = append(queries, fmt.Sprintf("%v BETWEEN ? AND ?", name))
+				args = append(args, from, to)
 			case LIKE:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` LIKE ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v LIKE ?", name))
+				args = append(args, val)
 			case INSENSITIVE_LIKE:
-				queries = append(queries, fmt.Sprintf("`%v`.`%v` ILIKE ?", table.Name, column.Name))
-				break
+				queries = append(queries, fmt.Sprintf("%v ILIKE ?", name))
+				args = append(args, val)
+			case STARTS_WITH:
+				queries = append(queries, fmt.Sprintf("%v LIKE ?", name))
+				args = append(args, escapeLike(val)+"%")
+			case ENDS_WITH:
+				queries = append(queries, fmt.Sprintf("%v LIKE ?", name))
+				args = append(args, "%"+escapeLike(val))
+			case CONTAINS:
+				queries = append(queries, fmt.Sprintf("%v LIKE ?", name))
+				args = append(args, "%"+escapeLike(val)+"%")
+			case REGEX:
+				if schema.Adapter == "postgres" {
+					queries = append(queries, fmt.Sprintf("%v ~ ?", name))
+				} else {
+					queries = append(queries, fmt.Sprintf("%v REGEXP ?", name))
+				}
+				args = append(args, val)
+			case IS_EMPTY:
+				if val.(bool) {
+					queries = append(queries, fmt.Sprintf("(%[1]v IS NULL OR %[1]v = '')", name))
+				} else {
+					queries = append(queries, fmt.Sprintf("(%[1]v IS NOT NULL AND %[1]v != '')", name))
+				}
+			case MATCH:
+				query, mode := parseMatch(val)
+				queries = append(queries, matchExpression(schema, table, []string{column.Name}, mode))
+				args = append(args, query)
 			default:
-				continue
+				panic(newRequestError(400, "`%v` is not support operator. (%v)", opName, name))
 			}
 		}
 
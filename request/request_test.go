@@ -1,6 +1,7 @@
 package request
 
 import (
+	"github.com/finwhale/octopus/core"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -139,8 +140,177 @@ func TestNode_Analyze(t *testing.T) {
 	assert.Contains(t, r.Node.Joins, Join{Origin: "user", Target: "article"})
 	assert.Contains(t, r.Node.Joins, Join{Origin: "role", Target: "role_type"})
 
-	assert.Contains(t, r.Node.Orders, "`user`.`name` ASC")
-	assert.Contains(t, r.Node.Orders, "`article`.`text` DESC")
+	assert.Contains(t, r.Node.Orders, Condition{Query: "`user`.`name` ASC"})
+	assert.Contains(t, r.Node.Orders, Condition{Query: "`article`.`text` DESC"})
 	assert.Contains(t, r.Node.Ors[0], Condition{Query: "`role`.`created_at` = ?", Args: []interface{}{"2017-6-17"}})
 	assert.Contains(t, r.Node.Ors[0], Condition{Query: "`role_type`.`name` = ?", Args: []interface{}{"ADMIN"}})
 }
+
+func TestParseQuery_Operators(t *testing.T) {
+	schema := core.GetSchema(false)
+	n := &Node{Name: "articleList", Type: "Article"}
+
+	cases := []struct {
+		op        string
+		val       interface{}
+		condition Condition
+	}{
+		{BETWEEN, []interface{}{1, 10}, Condition{Query: "`article`.`user_id` BETWEEN ? AND ?", Args: []interface{}{1, 10}}},
+		{STARTS_WITH, "50%_off", Condition{Query: "`article`.`text` LIKE ?", Args: []interface{}{"50\\%\\_off%"}}},
+		{ENDS_WITH, "c:\\", Condition{Query: "`article`.`text` LIKE ?", Args: []interface{}{"%c:\\\\"}}},
+		{CONTAINS, "foo", Condition{Query: "`article`.`text` LIKE ?", Args: []interface{}{"%foo%"}}},
+		{REGEX, "^foo", Condition{Query: "`article`.`text` REGEXP ?", Args: []interface{}{"^foo"}}},
+		{IS_EMPTY, true, Condition{Query: "(`article`.`text` IS NULL OR `article`.`text` = '')"}},
+		{IS_EMPTY, false, Condition{Query: "(`article`.`text` IS NOT NULL AND `article`.`text` != '')"}},
+	}
+
+	for _, c := range cases {
+		columnName := "text"
+		if c.op == BETWEEN {
+			columnName = "userId"
+		}
+
+		conditions, _ := parseQuery(map[string]interface{}{
+			columnName: map[string]interface{}{c.op: c.val},
+		}, n, schema)
+
+		assert.Equal(t, conditions, []Condition{c.condition}, c.op)
+	}
+}
+
+func TestParseQuery_InvalidOperator(t *testing.T) {
+	schema := core.GetSchema(false)
+	n := &Node{Name: "articleList", Type: "Article"}
+
+	assert.Panics(t, func() {
+		parseQuery(map[string]interface{}{"text": map[string]interface{}{"equal": "foo"}}, n, schema)
+	})
+
+	assert.Panics(t, func() {
+		parseQuery(map[string]interface{}{"userId": map[string]interface{}{BETWEEN: []interface{}{1}}}, n, schema)
+	})
+}
+
+func TestParseQuery_Not(t *testing.T) {
+	schema := core.GetSchema(false)
+	n := &Node{Name: "userList", Type: "User"}
+
+	conditions, joins := parseQuery(map[string]interface{}{
+		"_object": true,
+		NOT: map[string]interface{}{
+			"name": map[string]interface{}{IN: []string{"admin", "moderator"}},
+		},
+		"role": map[string]interface{}{
+			"_object": true,
+			NOT: map[string]interface{}{
+				"_object": true,
+				"roleType": map[string]interface{}{
+					"_object": true,
+					"name":    map[string]interface{}{EQUAL: "ORG"},
+				},
+			},
+		},
+	}, n, schema)
+
+	assert.Len(t, conditions, 2)
+	assert.Contains(t, conditions, Condition{
+		Query: "NOT (COALESCE((`user`.`name` IN (?)), FALSE))",
+		Args:  []interface{}{[]string{"admin", "moderator"}},
+	})
+	assert.Contains(t, conditions, Condition{
+		Query: "NOT (COALESCE((`role_type`.`name` = ?), FALSE))",
+		Args:  []interface{}{"ORG"},
+	})
+	assert.Contains(t, joins, Join{Origin: "user", Target: "role"})
+	assert.Contains(t, joins, Join{Origin: "role", Target: "role_type"})
+}
+
+func TestNegate(t *testing.T) {
+	negated, ok := negate([]Condition{
+		{Query: "`user`.`id` > ?", Args: []interface{}{3}},
+		{Query: "`user`.`name` IS NULL"},
+	})
+
+	assert.True(t, ok)
+	assert.Equal(t, negated, Condition{
+		Query: "NOT (COALESCE((`user`.`id` > ?) AND (`user`.`name` IS NULL), FALSE))",
+		Args:  []interface{}{3},
+	})
+
+	_, ok = negate([]Condition{})
+	assert.False(t, ok)
+}
+
+func TestParseOrder_List(t *testing.T) {
+	schema := core.GetSchema(false)
+	r := &Request{
+		Name:      "anonymous",
+		Operation: "query",
+		Node: &Node{
+			Name:   "articleList",
+			Type:   "Article",
+			IsList: true,
+			Args: map[string]interface{}{
+				ORDER: []interface{}{
+					map[string]interface{}{"text": map[string]interface{}{"to": "desc", NULLS: LAST}},
+					map[string]interface{}{"userId": ASC},
+					map[string]interface{}{
+						"user": map[string]interface{}{
+							"_object": true,
+							"name":    DESC,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var orders []Condition
+	var joins []Join
+	orderList := r.Node.Args[ORDER].([]interface{})
+
+	for _, o := range orderList {
+		os, js := parseOrder(o, r.Node, schema)
+		orders = append(orders, os...)
+		joins = append(joins, js...)
+	}
+
+	assert.Equal(t, orders, []Condition{
+		{Query: "`article`.`text` IS NULL ASC"},
+		{Query: "`article`.`text` DESC"},
+		{Query: "`article`.`user_id` ASC"},
+		{Query: "`user`.`name` DESC"},
+	})
+	assert.Equal(t, joins, []Join{{Origin: "article", Target: "user"}})
+
+	r.Node.Orders = orders
+	tiebreaker, ok := r.Node.tiebreaker()
+	assert.True(t, ok)
+	assert.Equal(t, tiebreaker, Condition{Query: "`article`.`id` ASC"})
+
+	r.Node.Orders = append(orders, Condition{Query: "`article`.`id` DESC"})
+	_, ok = r.Node.tiebreaker()
+	assert.False(t, ok)
+}
+
+func TestParseOrder_InvalidDirection(t *testing.T) {
+	schema := core.GetSchema(false)
+	n := &Node{Name: "articleList", Type: "Article"}
+
+	assert.Panics(t, func() {
+		parseOrder(map[string]interface{}{"text": "DESC; DROP TABLE article"}, n, schema)
+	})
+
+	assert.Panics(t, func() {
+		parseOrder(map[string]interface{}{"text": map[string]interface{}{"to": ASC, NULLS: "middle"}}, n, schema)
+	})
+}
+
+func TestRequest_IsStrong(t *testing.T) {
+	r := &Request{Operation: "query", Node: &Node{Name: "books", Args: map[string]interface{}{CONSISTENCY: STRONG}}}
+	assert.True(t, r.IsStrong())
+
+	r.Node.Args = map[string]interface{}{}
+	assert.False(t, r.IsStrong())
+	assert.False(t, (&Request{}).IsStrong())
+}
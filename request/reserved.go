@@ -19,7 +19,7 @@ func SetTotal(n *Node, _ *gorm.DB, data interface{}) {
 	}
 
 	total := -1
-	db := core.GetDB().Model(Get(n.Type))
+	db := n.scopeTenant(n.scopeSoftDelete(n.DB().Model(Get(n.Type))))
 	db.Count(&total)
 
 	data.(map[string]interface{})["_total"] = total
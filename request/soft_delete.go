@@ -0,0 +1,111 @@
+package request
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+// ------------------------------
+// Soft Delete
+// ------------------------------
+
+// 삭제 시각 컬럼이 있는 모델의 쿼리에서 삭제된 레코드를 제외합니다.
+// `_withDeleted`는 삭제된 레코드를 포함하고 `_onlyDeleted`는 삭제된 레코드만 조회하며, 모두 권한이 필요합니다.
+func (n *Node) scopeSoftDelete(db *gorm.DB) *gorm.DB {
+	table := core.GetSchema(false).GetTable(n.Type)
+
+	if table == nil || table.SoftDelete == "" {
+		return db
+	}
+
+	column := fmt.Sprintf("`%v`.`%v`", table.Name, table.SoftDelete)
+	withDeleted := n.Args[WITH_DELETED] == true
+	onlyDeleted := n.Args[ONLY_DELETED] == true
+
+	// 권한은 Result 에서 validateDeleted 로 먼저 검증되므로 여기서는 우회를 막기만 합니다.
+	if (withDeleted || onlyDeleted) && !GetAuthority(false).CanReadDeleted(n) {
+		panic(fmt.Errorf("No permission to read deleted `%v`.", n.Type))
+	}
+
+	if onlyDeleted {
+		return db.Where(fmt.Sprintf("%v IS NOT NULL", column))
+	}
+
+	if withDeleted {
+		return db
+	}
+
+	return db.Where(fmt.Sprintf("%v IS NULL", column))
+}
+
+// 노드와 하위 노드들 중 삭제된 레코드를 요청한 노드에 권한이 있는지 검증합니다.
+// 권한이 없다면 다른 권한 오류와 같이 401 코드를 담은 MutationError 를 반환합니다.
+func (n *Node) validateDeleted() error {
+	if errors := n.deletedErrors(); len(errors) > 0 {
+		return &MutationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (n *Node) deletedErrors() (errors []map[string]interface{}) {
+	if n.Args[WITH_DELETED] == true || n.Args[ONLY_DELETED] == true {
+		if !GetAuthority(false).CanReadDeleted(n) {
+			errors = append(errors, map[string]interface{}{
+				KEY:       n.Name,
+				"code":    401,
+				"message": fmt.Sprintf("No permission to read deleted `%v`.", n.Type),
+			})
+		}
+	}
+
+	for _, field := range n.Fields {
+		errors = append(errors, field.deletedErrors()...)
+	}
+
+	return
+}
+
+// 삭제 시각을 기록하여 레코드를 삭제합니다.
+func (n *Node) softDelete(table *core.Table, primary string, id interface{}) error {
+	query := fmt.Sprintf("UPDATE `%v` SET `%v` = ? WHERE `%v` = ?", table.Name, table.SoftDelete, primary)
+
+	return n.DB().Exec(query, time.Now(), id).Error
+}
+
+func (n *Node) restore() (*Result, error) {
+	schema := core.GetSchema(false)
+	table := schema.MustTable(n.Type)
+
+	if table.SoftDelete == "" {
+		return nil, newRequestError(400, "`%v` does not have a soft delete column.", n.Type)
+	}
+
+	primary, id, err := n.primary(schema, table)
+
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := n.find(table, primary, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = n.validateWrite(before); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("UPDATE `%v` SET `%v` = NULL WHERE `%v` = ?", table.Name, table.SoftDelete, primary)
+
+	if err = n.DB().Exec(query, id).Error; err != nil {
+		return nil, err
+	}
+
+	n.audit(table, id, before, n.load(table, primary, id))
+
+	return n.resolve(table, primary, id), nil
+}
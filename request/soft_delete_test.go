@@ -0,0 +1,38 @@
+package request
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNode_ValidateDeleted(t *testing.T) {
+	authority := parseAuthority(map[string]interface{}{
+		"default": "hasId(.userId)",
+	})
+	previous := cachedAuthority
+	cachedAuthority = &authority
+	defer func() { cachedAuthority = previous }()
+
+	r := &Request{user: &AnonymousUser{}, Operation: "query", Node: &Node{
+		Name: "bookList",
+		Type: "Book",
+		Args: map[string]interface{}{WITH_DELETED: true},
+		Fields: map[string]*Node{
+			"id": {Name: "id", Type: "Int"},
+		},
+	}}
+	r.SetUp()
+
+	result := r.Node.Result()
+	assert.Equal(t, result.Data, map[string]interface{}{
+		ERROR: map[string]interface{}{
+			DATA: []map[string]interface{}{
+				{KEY: "bookList", "code": 401, "message": "No permission to read deleted `Book`."},
+			},
+			COUNT: 1,
+		},
+	})
+
+	delete(r.Node.Args, WITH_DELETED)
+	assert.Nil(t, r.Node.validateDeleted())
+}
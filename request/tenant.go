@@ -0,0 +1,120 @@
+package request
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/jinzhu/gorm"
+	"reflect"
+)
+
+// ------------------------------
+// Tenant
+// ------------------------------
+
+// 요청의 테넌트를 반환합니다. 인증된 사용자의 테넌트를 우선으로 사용하고, 없다면 설정된 신뢰할 수 있는 헤더의 값을 사용합니다.
+func (r *Request) Tenant() interface{} {
+	if r.isTenantResolved {
+		return r.tenant
+	}
+
+	config := core.GetConfig(false).Tenant
+	field := config.Field
+
+	if field == "" {
+		field = core.CamelCase(config.Column)
+	}
+
+	if _, isAnonymous := r.GetUser().(*AnonymousUser); !isAnonymous {
+		r.tenant = indirect(core.Get(r.GetUser(), field))
+	}
+
+	if r.tenant == nil && config.Header != "" && r.Header != nil {
+		if value := r.Header.Get(config.Header); value != "" {
+			r.tenant = value
+		}
+	}
+
+	r.isTenantResolved = true
+
+	return r.tenant
+}
+
+// 테이블에 테넌트 컬럼이 있다면 요청의 테넌트를 반환합니다. 테넌트를 알 수 없는 요청은 어떤 데이터에도 접근할 수 없습니다.
+func (n *Node) tenant(table *core.Table) (tenant interface{}, err error) {
+	if table == nil || table.Tenant == "" {
+		return
+	}
+
+	if n.Request != nil {
+		tenant = n.Request.Tenant()
+	}
+
+	if tenant == nil {
+		err = newRequestError(401, "The tenant is required to access `%v`.", core.Classify(table.Name))
+	}
+
+	return
+}
+
+// 노드의 테이블과 조인된 테이블들을 요청의 테넌트로 제한합니다.
+// 조인된 테이블은 LEFT JOIN 으로 연결될 수 있으므로 연결된 레코드가 없는 경우는 제외하지 않습니다.
+func (n *Node) scopeTenant(db *gorm.DB) *gorm.DB {
+	schema := core.GetSchema(false)
+	table := schema.GetTable(n.Type)
+	tenant, err := n.tenant(table)
+	core.Check(err)
+
+	if table != nil && table.Tenant != "" {
+		db = db.Where(fmt.Sprintf("`%v`.`%v` = ?", table.Name, table.Tenant), tenant)
+	}
+
+	for _, join := range n.Joins {
+		target := schema.GetTable(join.Target)
+		tenant, err := n.tenant(target)
+		core.Check(err)
+
+		if target != nil && target.Tenant != "" {
+			column := fmt.Sprintf("`%v`.`%v`", target.Name, target.Tenant)
+			db = db.Where(fmt.Sprintf("(%[1]v = ? OR %[1]v IS NULL)", column), tenant)
+		}
+	}
+
+	return db
+}
+
+// 생성되는 레코드에는 클라이언트가 전달한 값과 관계없이 요청의 테넌트를 기록하고, 수정할 때에는 테넌트를 바꿀 수 없습니다.
+func (n *Node) stampTenant(table *core.Table, values map[string]interface{}, isCreate bool) error {
+	if table.Tenant == "" {
+		return nil
+	}
+
+	delete(values, table.Tenant)
+
+	if !isCreate {
+		return nil
+	}
+
+	tenant, err := n.tenant(table)
+
+	if err != nil {
+		return err
+	}
+
+	values[table.Tenant] = tenant
+
+	return nil
+}
+
+func indirect(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+
+		return v.Elem().Interface()
+	}
+
+	return value
+}
@@ -0,0 +1,49 @@
+package request
+
+import (
+	"github.com/finwhale/octopus/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNode_StampTenant(t *testing.T) {
+	table := &core.Table{Name: "book", Tenant: "tenant_id"}
+	n := &Node{Name: "createBook", Type: "Book", Request: &Request{tenant: 3, isTenantResolved: true}}
+
+	values := map[string]interface{}{"title": "foo", "tenant_id": 9}
+	assert.Nil(t, n.stampTenant(table, values, true))
+	assert.Equal(t, values["tenant_id"], 3)
+
+	values = map[string]interface{}{"title": "foo", "tenant_id": 9}
+	assert.Nil(t, n.stampTenant(table, values, false))
+	assert.NotContains(t, values, "tenant_id")
+
+	n.Request = &Request{isTenantResolved: true}
+	err := n.stampTenant(table, map[string]interface{}{"title": "foo"}, true)
+	assert.EqualError(t, err, "The tenant is required to access `Book`.")
+}
+
+func TestNode_Tenant(t *testing.T) {
+	n := &Node{Name: "books", Type: "Book"}
+
+	tenant, err := n.tenant(&core.Table{Name: "book"})
+	assert.Nil(t, tenant)
+	assert.Nil(t, err)
+
+	_, err = n.tenant(&core.Table{Name: "book", Tenant: "tenant_id"})
+	assert.EqualError(t, err, "The tenant is required to access `Book`.")
+
+	n.Request = &Request{tenant: "acme", isTenantResolved: true}
+	tenant, err = n.tenant(&core.Table{Name: "book", Tenant: "tenant_id"})
+	assert.Equal(t, tenant, "acme")
+	assert.Nil(t, err)
+}
+
+func TestIndirect(t *testing.T) {
+	id := int64(3)
+	var nilId *int64
+
+	assert.Equal(t, indirect(&id), int64(3))
+	assert.Nil(t, indirect(nilId))
+	assert.Equal(t, indirect("acme"), "acme")
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"github.com/finwhale/octopus/core"
+	"time"
+)
+
+// ------------------------------
+// Timestamp
+// ------------------------------
+
+// 클라이언트가 전달한 생성, 수정 시각은 무시하고 서버의 시각으로 채웁니다.
+// 생성 시각은 레코드를 생성할 때에만 기록됩니다.
+func stampTimestamps(table *core.Table, values map[string]interface{}, isCreate bool) {
+	now := time.Now()
+
+	if table.CreatedAt != "" {
+		delete(values, table.CreatedAt)
+
+		if isCreate {
+			values[table.CreatedAt] = now
+		}
+	}
+
+	if table.UpdatedAt != "" {
+		values[table.UpdatedAt] = now
+	}
+}
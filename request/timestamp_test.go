@@ -0,0 +1,28 @@
+package request
+
+import (
+	"github.com/finwhale/octopus/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestStampTimestamps(t *testing.T) {
+	table := &core.Table{Name: "book", CreatedAt: "created_at", UpdatedAt: "updated_at"}
+	skewed := "1999-12-31 23:59:59"
+
+	values := map[string]interface{}{"title": "foo", "created_at": skewed, "updated_at": skewed}
+	stampTimestamps(table, values, true)
+	assert.IsType(t, time.Time{}, values["created_at"])
+	assert.IsType(t, time.Time{}, values["updated_at"])
+	assert.Equal(t, values["title"], "foo")
+
+	values = map[string]interface{}{"title": "foo", "created_at": skewed}
+	stampTimestamps(table, values, false)
+	assert.NotContains(t, values, "created_at")
+	assert.IsType(t, time.Time{}, values["updated_at"])
+
+	values = map[string]interface{}{"created_at": skewed}
+	stampTimestamps(&core.Table{Name: "log"}, values, true)
+	assert.Equal(t, values["created_at"], skewed)
+}
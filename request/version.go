@@ -0,0 +1,43 @@
+package request
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+)
+
+// ------------------------------
+// Version
+// ------------------------------
+
+// 생성되는 레코드의 버전은 클라이언트가 전달한 값과 관계없이 0 부터 시작합니다.
+func stampVersion(table *core.Table, values map[string]interface{}) {
+	if table.Version != "" {
+		values[table.Version] = 0
+	}
+}
+
+// 수정 뮤테이션에 전달된 버전을 꺼냅니다. 버전 컬럼이 있는 모델은 클라이언트가 마지막으로 읽은 버전이 반드시 필요합니다.
+func (n *Node) lockVersion(table *core.Table, values map[string]interface{}) (interface{}, error) {
+	version, exist := values[table.Version]
+	delete(values, table.Version)
+
+	if !exist || version == nil {
+		return nil, newRequestError(400, "`%v` is required to %v `%v`.", core.CamelCase(table.Version), n.action(), n.Type)
+	}
+
+	return version, nil
+}
+
+// 다른 요청이 먼저 레코드를 수정한 경우 현재 레코드를 담은 충돌 오류를 반환합니다.
+func (n *Node) conflict(table *core.Table, primary string, id interface{}, version interface{}) error {
+	return &MutationError{
+		Errors: []map[string]interface{}{
+			{
+				KEY:       n.Name,
+				"code":    409,
+				"message": fmt.Sprintf("`%v` of `%v` has been modified since version `%v`.", id, n.Type, version),
+				"current": n.resolve(table, primary, id).Data,
+			},
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package request
+
+import (
+	"github.com/finwhale/octopus/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStampVersion(t *testing.T) {
+	values := map[string]interface{}{"title": "foo", "lock_version": 7}
+	stampVersion(&core.Table{Name: "book", Version: "lock_version"}, values)
+	assert.Equal(t, values["lock_version"], 0)
+
+	values = map[string]interface{}{"title": "foo"}
+	stampVersion(&core.Table{Name: "book"}, values)
+	assert.NotContains(t, values, "lock_version")
+}
+
+func TestNode_LockVersion(t *testing.T) {
+	table := &core.Table{Name: "book", Version: "lock_version"}
+	n := &Node{Name: "updateBook", Type: "Book"}
+
+	values := map[string]interface{}{"title": "foo", "lock_version": 3}
+	version, err := n.lockVersion(table, values)
+	assert.Nil(t, err)
+	assert.Equal(t, version, 3)
+	assert.NotContains(t, values, "lock_version")
+
+	_, err = n.lockVersion(table, map[string]interface{}{"title": "foo"})
+	assert.EqualError(t, err, "`lockVersion` is required to update `Book`.")
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/labstack/echo"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	authorization = "authorization"
+
+	// JWT 에서 사용자 ID 를 가진 기본 클레임입니다. leadoff 의 auth.js 가 발급하는 토큰과 같습니다.
+	DefaultUserClaim = "user_id"
+)
+
+// REST 요청의 사용자 ID 를 검증된 자격 증명에서 찾습니다.
+// 신뢰할 수 있는 프록시의 헤더(rest.userHeader)가 설정되어 있다면 그 값을, 아니라면 `Authorization: JWT <token>` 헤더 또는 쿠키의
+// 토큰을 rest.secret 으로 검증하여 클레임의 값을 사용합니다. 자격 증명이 없다면 빈 문자열을 반환하며 익명 사용자로 실행됩니다.
+func restUserId(c echo.Context) (interface{}, error) {
+	config := core.GetConfig(false).Rest
+	req := c.Request()
+
+	if config.UserHeader != "" {
+		return req.Header.Get(config.UserHeader), nil
+	}
+
+	credential := req.Header.Get(echo.HeaderAuthorization)
+
+	if credential == "" {
+		if cookie, err := req.Cookie(authorization); err == nil {
+			credential = cookie.Value
+		}
+	}
+
+	if credential == "" {
+		return "", nil
+	}
+
+	if config.Secret == "" {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "rest.secret is not configured. Credentials can not be verified.")
+	}
+
+	parts := strings.SplitN(credential, " ", 2)
+
+	if len(parts) != 2 || (!strings.EqualFold(parts[0], "jwt") && !strings.EqualFold(parts[0], "bearer")) {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "The authorization must be `JWT <token>`.")
+	}
+
+	claim := config.Claim
+
+	if claim == "" {
+		claim = DefaultUserClaim
+	}
+
+	claims, err := verifyJWT(parts[1], config.Secret, time.Now())
+
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+
+	userId, exist := claims[claim]
+
+	if !exist || userId == nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("The token does not have `%v` claim.", claim))
+	}
+
+	// JSON 의 숫자는 float64 로 해석되므로 정수라면 정수로 비교되도록 바꿉니다.
+	if number, ok := userId.(float64); ok && number == float64(int64(number)) {
+		return int64(number), nil
+	}
+
+	return userId, nil
+}
+
+// HS256 으로 서명된 JWT 를 검증하고 클레임을 반환합니다. `exp`가 있다면 만료 여부도 확인합니다.
+func verifyJWT(token string, secret string, now time.Time) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("The token is malformed.")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, fmt.Errorf("The token must be signed with HS256.")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, fmt.Errorf("The token is malformed.")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("The token signature is invalid.")
+	}
+
+	var claims map[string]interface{}
+
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("The token is malformed.")
+	}
+
+	if exp, ok := claims["exp"].(float64); ok && now.Unix() >= int64(exp) {
+		return nil, fmt.Errorf("The token has expired.")
+	}
+
+	return claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
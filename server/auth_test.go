@@ -0,0 +1,112 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testSecret = "s3cret"
+
+func signJWT(claims map[string]interface{}, secret string) string {
+	header, _ := json.Marshal(map[string]interface{}{"alg": "HS256", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func setUpAuth(config *core.Config) func() {
+	previous := core.GetConfig(false)
+	core.SetConfig(config)
+
+	return func() { core.SetConfig(previous) }
+}
+
+func restContext(header string, value string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/rest/books", nil)
+
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestRestUserId_JWT(t *testing.T) {
+	config := &core.Config{}
+	config.Rest.Secret = testSecret
+	defer setUpAuth(config)()
+
+	token := signJWT(map[string]interface{}{"user_id": 7, "exp": time.Now().Add(time.Hour).Unix()}, testSecret)
+	userId, err := restUserId(restContext(echo.HeaderAuthorization, "JWT "+token))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), userId)
+
+	req := httptest.NewRequest(http.MethodGet, "/rest/books", nil)
+	req.AddCookie(&http.Cookie{Name: authorization, Value: "jwt " + token})
+	userId, err = restUserId(echo.New().NewContext(req, httptest.NewRecorder()))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), userId)
+
+	userId, err = restUserId(restContext("", ""))
+	assert.Nil(t, err)
+	assert.Equal(t, "", userId)
+
+	forged := signJWT(map[string]interface{}{"user_id": 7}, "other")
+	_, err = restUserId(restContext(echo.HeaderAuthorization, "JWT "+forged))
+	assert.EqualError(t, err, "code=401, message=The token signature is invalid.")
+
+	expired := signJWT(map[string]interface{}{"user_id": 7, "exp": time.Now().Add(-time.Hour).Unix()}, testSecret)
+	_, err = restUserId(restContext(echo.HeaderAuthorization, "JWT "+expired))
+	assert.EqualError(t, err, "code=401, message=The token has expired.")
+
+	anonymous := signJWT(map[string]interface{}{"name": "kim"}, testSecret)
+	_, err = restUserId(restContext(echo.HeaderAuthorization, "JWT "+anonymous))
+	assert.EqualError(t, err, "code=401, message=The token does not have `user_id` claim.")
+}
+
+func TestRestUserId_Header(t *testing.T) {
+	config := &core.Config{}
+	config.Rest.UserHeader = "X-User-Id"
+	defer setUpAuth(config)()
+
+	userId, err := restUserId(restContext("X-User-Id", "7"))
+	assert.Nil(t, err)
+	assert.Equal(t, "7", userId)
+
+	// 프록시를 신뢰하는 경우 Authorization 헤더는 사용하지 않습니다.
+	userId, err = restUserId(restContext(echo.HeaderAuthorization, "JWT "+signJWT(map[string]interface{}{"user_id": 1}, testSecret)))
+	assert.Nil(t, err)
+	assert.Equal(t, "", userId)
+}
+
+func TestRestHandler_Unauthorized(t *testing.T) {
+	defer setUpRest(t)()
+
+	config := &core.Config{}
+	config.Rest.Secret = testSecret
+	defer setUpAuth(config)()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/rest/books", nil)
+	req.Header.Set(echo.HeaderAuthorization, "JWT "+signJWT(map[string]interface{}{"user_id": 7}, "other"))
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("model")
+	c.SetParamValues("books")
+
+	err := restHandler(request.GET)(c)
+	assert.EqualError(t, err, "code=401, message=The token signature is invalid.")
+}
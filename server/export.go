@@ -0,0 +1,106 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/farmer"
+	"github.com/finwhale/octopus/request"
+	"github.com/labstack/echo"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// 목록 쿼리를 페이지 제한 없이 스트리밍합니다. 본문은 `/`와 같은 요청이며 형식은 최상위 노드의 `_format` 인자로 지정합니다.
+func export(c echo.Context) error {
+	body, err := ioutil.ReadAll(c.Request().Body)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	r := new(request.Request)
+
+	if err = json.Unmarshal(body, r); err != nil || r.Node == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "The body must be a request with a node.")
+	}
+
+	r.Header = c.Request().Header
+
+	return streamExport(c, r)
+}
+
+// 헤더는 첫 번째 쓰기에서 설정합니다. 쓰기 전에 실패하면 JSON 오류 응답이 내보내기 형식으로 표시되지 않습니다.
+type exportResponse struct {
+	response    *echo.Response
+	contentType string
+	filename    string
+}
+
+func streamExport(c echo.Context, r *request.Request) error {
+	format := farmer.ExportFormat(r)
+	contentType, err := request.ExportContentType(format)
+
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	w := &exportResponse{response: c.Response(), contentType: contentType, filename: r.Node.Name + "." + format}
+	err = safeExport(r, w, core.GetConfig(false).Export.ChunkSize)
+
+	if err == nil {
+		w.start()
+		return nil
+	}
+
+	err = publicError(c, r, err)
+
+	if !c.Response().Committed {
+		result := request.ErrorResult(r.Node.Name, err)
+		status, _ := restStatus(result)
+
+		return c.JSON(status, result)
+	}
+
+	// 이미 응답을 쓰기 시작했다면 상태 코드를 바꿀 수 없으므로 마지막에 오류를 남겨 잘린 응답임을 알립니다.
+	r.Node.WriteExportError(c.Response(), format, err)
+
+	return nil
+}
+
+func (w *exportResponse) start() {
+	if w.response.Committed {
+		return
+	}
+
+	header := w.response.Header()
+	header.Set(echo.HeaderContentType, w.contentType)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", w.filename))
+	w.response.WriteHeader(http.StatusOK)
+}
+
+func (w *exportResponse) Write(b []byte) (int, error) {
+	w.start()
+
+	return w.response.Write(b)
+}
+
+func (w *exportResponse) Flush() {
+	w.response.Flush()
+}
+
+// 내보내기 중 발생한 패닉을 오류로 변환합니다.
+func safeExport(r *request.Request, w io.Writer, chunkSize int) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			var ok bool
+
+			if err, ok = recovered.(error); !ok {
+				err = fmt.Errorf("%v", recovered)
+			}
+		}
+	}()
+
+	return farmer.Export(r, w, chunkSize)
+}
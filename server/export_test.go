@@ -0,0 +1,71 @@
+package server
+
+import (
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExport_BadRequest(t *testing.T) {
+	e := echo.New()
+	e.POST("/export", export)
+
+	for body, message := range map[string]string{
+		`{"name":"books"}`: "The body must be a request with a node.",
+		`{"operation":"query","node":{"name":"bookList","type":"Book","isList":true,"args":{"_format":"xml"}}}`: "`xml` is not support export format.",
+	} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/export", strings.NewReader(body))
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		assert.Contains(t, rec.Body.String(), message, body)
+	}
+}
+
+func TestRestHandler_Export(t *testing.T) {
+	defer setUpRest(t)()
+
+	e := echo.New()
+	registerRest(e)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/rest/books?_format=xml", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "`xml` is not support export format.")
+}
+
+func TestExport_ErrorBeforeWrite(t *testing.T) {
+	e := echo.New()
+	e.POST("/export", export)
+
+	rec := httptest.NewRecorder()
+	body := `{"operation":"query","node":{"name":"book","type":"Book","args":{"_format":"csv"}}}`
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/export", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Contains(t, rec.Body.String(), "`book` is not a list. Only a list can be exported.")
+}
+
+func TestExportResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/export", nil), rec)
+	w := &exportResponse{response: c.Response(), contentType: "text/csv; charset=utf-8", filename: "bookList.csv"}
+
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentType))
+	assert.False(t, c.Response().Committed)
+
+	w.Write([]byte("id,_error\n"))
+	w.Write([]byte("1,\n"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="bookList.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "id,_error\n1,\n", rec.Body.String())
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/labstack/echo"
+	"net/http"
+	"sync/atomic"
+)
+
+// 서버가 종료 중이라면 1 입니다. 종료 중에는 새로운 트래픽을 받지 않도록 준비되지 않은 것으로 응답합니다.
+var draining int32
+
+// 프로세스가 살아있다면 항상 성공합니다.
+func healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// 데이터베이스에 연결할 수 있고 스키마와 설정을 불러온 경우에만 요청을 받을 준비가 된 것으로 응답합니다.
+func readyz(env string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := ready(env); err != nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{"status": "unavailable", "message": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"status": "ok"})
+	}
+}
+
+func ready(env string) (err error) {
+	// 설정, 스키마를 불러오지 못하거나 데이터베이스가 설정되지 않은 경우 패닉이 발생하므로 준비되지 않은 것으로 응답합니다.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+
+	if atomic.LoadInt32(&draining) == 1 {
+		return fmt.Errorf("The server is shutting down.")
+	}
+
+	if _, exist := core.GetConfig(false).Database[env]; !exist {
+		return fmt.Errorf("The database of `%v` is not configured in the `%v` file.", env, core.ConfigFilename)
+	}
+
+	if len(core.GetSchema(false).Tables) == 0 {
+		return fmt.Errorf("The schema is not loaded. Check the `%v` file.", core.DBFilename)
+	}
+
+	return core.GetDB().DB().Ping()
+}
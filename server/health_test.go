@@ -0,0 +1,35 @@
+package server
+
+import (
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHealthz(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/healthz", nil), rec)
+
+	assert.Nil(t, healthz(c))
+	assert.Equal(t, rec.Code, http.StatusOK)
+}
+
+func TestReadyz(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec)
+
+	assert.Nil(t, readyz("unknown")(c))
+	assert.Equal(t, rec.Code, http.StatusServiceUnavailable)
+	assert.True(t, strings.Contains(rec.Body.String(), "`unknown` is not configured"))
+
+	atomic.StoreInt32(&draining, 1)
+	defer atomic.StoreInt32(&draining, 0)
+
+	assert.EqualError(t, ready("test"), "The server is shutting down.")
+}
@@ -0,0 +1,487 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/farmer"
+	"github.com/finwhale/octopus/request"
+	"github.com/jinzhu/inflection"
+	"github.com/labstack/echo"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// REST 엔드포인트의 경로입니다.
+const RestPrefix = "/rest"
+
+const (
+	restName   = "rest"
+	restFields = "_fields"
+)
+
+var (
+	// `title[like]`, `author.name`처럼 컬럼의 경로와 연산자로 이루어진 쿼리 스트링의 키입니다.
+	restFilterRegex = regexp.MustCompile(`^([a-zA-Z0-9_.]+)(?:\[([a-zA-Z]+)\])?$`)
+
+	restOperators = []string{
+		request.EQUAL, request.NOT_EQUAL, request.IN, request.NOT_IN, request.NIL,
+		request.LESS_THAN, request.LESS_THAN_EQUAL, request.GREAT_THAN, request.GREAT_THAN_EQUAL, request.BETWEEN,
+		request.LIKE, request.INSENSITIVE_LIKE, request.STARTS_WITH, request.ENDS_WITH, request.CONTAINS,
+		request.REGEX, request.IS_EMPTY, request.MATCH,
+	}
+
+	// 쉼표로 구분된 목록을 값으로 받는 연산자들입니다.
+	restListOperators = []string{request.IN, request.NOT_IN, request.BETWEEN}
+
+	// 참, 거짓을 값으로 받는 연산자들입니다.
+	restBoolOperators = []string{request.NIL, request.IS_EMPTY}
+)
+
+// db.json 의 모든 테이블을 REST 로 제공합니다.
+// 요청은 request.Node 로 변환되어 실행되므로 권한, 커스텀 필드, 커스텀 뮤테이션이 GraphQL 요청과 동일하게 적용됩니다.
+// 사용자는 restUserId 로 검증된 자격 증명에서 찾으므로 외부에 직접 노출해도 되는 것은 이 그룹뿐입니다.
+// `POST /`와 `/export`는 요청 본문의 userId 를 그대로 믿으므로 인증을 처리하는 Node 서버 뒤에만 두어야 합니다.
+func registerRest(e *echo.Echo) {
+	g := e.Group(RestPrefix)
+
+	g.GET("/:model", restHandler(request.GET))
+	g.GET("/:model/:id", restHandler(request.GET))
+	g.POST("/:model", restHandler(request.CREATE))
+	g.PATCH("/:model/:id", restHandler(request.UPDATE))
+	g.DELETE("/:model/:id", restHandler(request.DELETE))
+}
+
+func restHandler(action string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var body map[string]interface{}
+
+		if action == request.CREATE || action == request.UPDATE {
+			b, err := ioutil.ReadAll(c.Request().Body)
+
+			if err == nil {
+				err = json.Unmarshal(b, &body)
+			}
+
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("The body must be a JSON object. (%v)", err))
+			}
+		}
+
+		r, err := newRestRequest(action, c.Param("model"), c.Param("id"), c.QueryParams(), body)
+
+		if err != nil {
+			return err
+		}
+
+		if r.UserId, err = restUserId(c); err != nil {
+			return err
+		}
+
+		r.Header = restHeader(c)
+
+		if _, exist := r.Node.Args[request.EXPORT_FORMAT]; exist && r.Node.IsList {
+			return streamExport(c, r)
+		}
+
+		result := restExec(c, r)
+
+		if status, failed := restStatus(result); failed {
+			return c.JSON(status, result)
+		}
+
+		if action == request.GET && !r.Node.IsList && result.Data == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("`%v` of `%v` does not exist.", c.Param("id"), r.Node.Type))
+		}
+
+		if action == request.CREATE {
+			return c.JSON(http.StatusCreated, result)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// REST 요청의 헤더를 반환합니다. 사용자를 프록시의 헤더로 받는 경우에만 테넌트 헤더도 프록시가 보낸 것으로 믿으며,
+// 그 외에는 클라이언트가 임의의 테넌트에 접근할 수 없도록 설정된 테넌트 헤더를 제거합니다.
+func restHeader(c echo.Context) http.Header {
+	config := core.GetConfig(false)
+	header := c.Request().Header
+
+	if config.Tenant.Header == "" || config.Rest.UserHeader != "" {
+		return header
+	}
+
+	header = header.Clone()
+	header.Del(config.Tenant.Header)
+
+	return header
+}
+
+// REST 요청을 최상위 노드 하나를 가진 요청으로 변환합니다. id 가 있다면 하나의 레코드를, 없다면 목록을 대상으로 합니다.
+// 뮤테이션은 `createBook`처럼 이름이 정해지므로 같은 이름의 커스텀 뮤테이션이 있다면 그것이 실행됩니다.
+func newRestRequest(action string, model string, id string, query url.Values, body map[string]interface{}) (*request.Request, error) {
+	schema := core.GetSchema(false)
+	table := schema.GetTable(model)
+
+	if table == nil {
+		table = schema.GetTable(inflection.Singular(model))
+	}
+
+	if table == nil || request.Get(table.Name) == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("`%v` model does not exist.", model))
+	}
+
+	primary, err := schema.GetPrimary(table.Name)
+
+	if err != nil && id != "" {
+		return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	n := &request.Node{
+		Name:   core.CamelCase(table.Name),
+		Type:   core.Classify(table.Name),
+		Args:   map[string]interface{}{},
+		Fields: map[string]*request.Node{},
+	}
+
+	r := &request.Request{Name: restName, Operation: "query", Node: n}
+
+	if action != request.GET {
+		r.Operation = request.MUTATION
+		n.Name = action + n.Type
+
+		for name, value := range body {
+			n.Args[name] = value
+		}
+
+		if id != "" {
+			n.Args[core.CamelCase(primary)] = id
+		}
+	} else {
+		if err = parseRestQuery(schema, table, query, n.Args); err != nil {
+			return nil, err
+		}
+
+		if id != "" {
+			where, _ := n.Args[request.WHERE].(map[string]interface{})
+
+			if where == nil {
+				where = map[string]interface{}{}
+				n.Args[request.WHERE] = where
+			}
+
+			where[core.CamelCase(primary)] = map[string]interface{}{request.EQUAL: id}
+		} else {
+			n.Name += "List"
+			n.IsList = true
+		}
+	}
+
+	if err = parseRestFields(schema, table, query.Get(restFields), n); err != nil {
+		return nil, err
+	}
+
+	// 목록은 `_data`로 감싸고 조건에 맞는 레코드의 수와 페이지 정보를 함께 반환합니다.
+	if n.IsList {
+		for _, name := range []string{request.COUNT, request.LIMIT, request.OFFSET} {
+			n.Fields[name] = &request.Node{Name: name, Type: "Int", IsLeaf: true}
+		}
+	}
+
+	return r, nil
+}
+
+// `_fields=id,title`로 응답에 포함할 필드를 고릅니다. 지정하지 않으면 테이블의 모든 컬럼을 반환합니다.
+// 모델에 정의된 커스텀 필드도 이름으로 요청할 수 있습니다.
+func parseRestFields(schema *core.Schema, table *core.Table, raw string, n *request.Node) error {
+	var names []string
+
+	if raw == "" {
+		for name := range table.Columns {
+			names = append(names, name)
+		}
+	} else {
+		names = strings.Split(raw, ",")
+	}
+
+	for _, name := range names {
+		name = core.CamelCase(strings.TrimSpace(name))
+
+		if name == "" || strings.HasPrefix(name, "_") {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` is not a valid field.", raw))
+		}
+
+		field := &request.Node{Name: name, Type: "String", IsLeaf: true, Args: map[string]interface{}{}}
+
+		if column := schema.GetColumn(table.Name, name); column != nil {
+			field.Name = core.CamelCase(column.Name)
+		} else if !isCustomField(table, name) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` is not a field of `%v`.", name, core.Classify(table.Name)))
+		}
+
+		n.Fields[field.Name] = field
+	}
+
+	return nil
+}
+
+// 모델에 `GetTitle`, `BulkTitle`처럼 필드를 채우는 메서드가 있는지 확인합니다.
+func isCustomField(table *core.Table, name string) bool {
+	model := reflect.ValueOf(request.Get(table.Name))
+
+	for _, prefix := range []string{request.GET, request.BULK} {
+		if model.MethodByName(core.EncapCase(prefix, name)).IsValid() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// 쿼리 스트링을 `_where`, `_order`, `_limit`, `_offset` 등의 인자로 변환합니다.
+//
+//	?title[like]=Go%&price[between]=10,20&author.name=kim&_order=-createdAt,title&_limit=10
+func parseRestQuery(schema *core.Schema, table *core.Table, query url.Values, args map[string]interface{}) error {
+	where := map[string]interface{}{}
+
+	for key, values := range query {
+		value := values[len(values)-1]
+
+		switch key {
+		case restFields:
+			continue
+		case request.LIMIT, request.OFFSET:
+			number, err := strconv.ParseFloat(value, 64)
+
+			if err != nil || number < 0 {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` must be a positive number.", key))
+			}
+
+			args[key] = number
+			continue
+		case request.ORDER:
+			orders, err := parseRestOrder(schema, table, value)
+
+			if err != nil {
+				return err
+			}
+
+			args[key] = orders
+			continue
+		case request.WITH_DELETED, request.ONLY_DELETED:
+			b, err := strconv.ParseBool(value)
+
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` must be true or false.", key))
+			}
+
+			args[key] = b
+			continue
+		case request.CONSISTENCY, request.EXPORT_FORMAT:
+			args[key] = value
+			continue
+		}
+
+		matched := restFilterRegex.FindStringSubmatch(key)
+
+		if matched == nil || strings.HasPrefix(key, "_") {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` is not a valid parameter.", key))
+		}
+
+		operator := matched[2]
+
+		if operator == "" {
+			operator = request.EQUAL
+
+			// 같은 컬럼을 여러 번 지정하면 그 중 하나와 일치하는 레코드를 찾습니다.
+			if len(values) > 1 {
+				operator = request.IN
+			}
+		}
+
+		if !core.Contains(restOperators, operator) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` is not support operator. (%v)", operator, key))
+		}
+
+		condition, err := restPath(schema, table, matched[1], where)
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case operator == request.IN && len(values) > 1:
+			condition[operator] = values
+		case core.Contains(restListOperators, operator):
+			condition[operator] = strings.Split(value, ",")
+		case core.Contains(restBoolOperators, operator):
+			b, err := strconv.ParseBool(value)
+
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` must be true or false.", key))
+			}
+
+			condition[operator] = b
+		default:
+			condition[operator] = value
+		}
+	}
+
+	if len(where) > 0 {
+		args[request.WHERE] = where
+	}
+
+	return nil
+}
+
+// `_order=-createdAt,author.name`처럼 쉼표로 구분하며 `-`로 시작하면 내림차순입니다.
+func parseRestOrder(schema *core.Schema, table *core.Table, raw string) ([]interface{}, error) {
+	var orders []interface{}
+
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		direction := request.ASC
+
+		if strings.HasPrefix(name, "-") {
+			name = name[1:]
+			direction = request.DESC
+		}
+
+		order := map[string]interface{}{}
+		parent := order
+		segments := strings.Split(name, ".")
+		current := table
+
+		for _, segment := range segments[:len(segments)-1] {
+			current = schema.GetTable(segment)
+
+			if current == nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` table does not exist. (%v)", segment, request.ORDER))
+			}
+
+			child := map[string]interface{}{"_object": true}
+			parent[core.CamelCase(segment)] = child
+			parent = child
+		}
+
+		column := schema.GetColumn(current.Name, segments[len(segments)-1])
+
+		if column == nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` column does not exist in `%v` table. (%v)", segments[len(segments)-1], current.Name, request.ORDER))
+		}
+
+		parent[core.CamelCase(column.Name)] = direction
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+// `author.name`처럼 점으로 구분된 경로를 따라 조건 맵을 만들고 컬럼의 연산자 맵을 반환합니다.
+// 다른 테이블의 조건은 `_object`를 붙여 JoinX 메서드로 조인되도록 합니다.
+func restPath(schema *core.Schema, table *core.Table, path string, where map[string]interface{}) (map[string]interface{}, error) {
+	segments := strings.Split(path, ".")
+	parent := where
+	current := table
+
+	for _, segment := range segments[:len(segments)-1] {
+		current = schema.GetTable(segment)
+
+		if current == nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` table does not exist. (%v)", segment, path))
+		}
+
+		child, ok := parent[core.CamelCase(segment)].(map[string]interface{})
+
+		if !ok {
+			child = map[string]interface{}{"_object": true}
+			parent[core.CamelCase(segment)] = child
+		}
+
+		parent = child
+	}
+
+	column := schema.GetColumn(current.Name, segments[len(segments)-1])
+
+	if column == nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("`%v` column does not exist in `%v` table.", segments[len(segments)-1], current.Name))
+	}
+
+	condition, ok := parent[core.CamelCase(column.Name)].(map[string]interface{})
+
+	if !ok {
+		condition = map[string]interface{}{}
+		parent[core.CamelCase(column.Name)] = condition
+	}
+
+	return condition, nil
+}
+
+// 요청을 실행하고 패닉이 발생한 경우 일괄 요청과 같이 `_error` 형태의 결과로 변환합니다.
+func restExec(c echo.Context, r *request.Request) (result *request.Result) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err, ok := recovered.(error)
+
+			if !ok {
+				err = fmt.Errorf("%v", recovered)
+			}
+
+			result = request.ErrorResult(r.Node.Name, publicError(c, r, err))
+		}
+	}()
+
+	executed := farmer.Exec(r)
+
+	if casted, ok := executed.(*request.Result); ok && casted != nil {
+		return casted
+	}
+
+	return &request.Result{Data: executed}
+}
+
+// 잘못된 요청으로 발생한 오류는 그대로 반환합니다. 그 외의 오류는 SQL 이나 드라이버의 내용이 외부에 노출되지 않도록
+// 서버의 로그에만 남기고 요청 ID 를 가진 일반적인 메시지로 바꿉니다.
+func publicError(c echo.Context, r *request.Request, err error) error {
+	switch err.(type) {
+	case *request.MutationError, *request.RequestError:
+		return err
+	}
+
+	c.Logger().Errorf("%v %v: %v", c.Request().Method, c.Request().URL.Path, err)
+
+	if id := r.Id(); id != "" {
+		return fmt.Errorf("An unexpected error occurred. (request id: %v)", id)
+	}
+
+	return fmt.Errorf("An unexpected error occurred.")
+}
+
+// `_error` 형태의 결과라면 첫 번째 오류의 코드를 HTTP 상태 코드로 사용합니다.
+func restStatus(result *request.Result) (int, bool) {
+	data, ok := result.Data.(map[string]interface{})
+
+	if !ok {
+		return http.StatusOK, false
+	}
+
+	errorMap, ok := data[request.ERROR].(map[string]interface{})
+
+	if !ok {
+		return http.StatusOK, false
+	}
+
+	if errors, ok := errorMap[request.DATA].([]map[string]interface{}); ok && len(errors) > 0 {
+		if code, ok := errors[0]["code"].(int); ok && code >= 400 && code < 600 {
+			return code, true
+		}
+	}
+
+	return http.StatusInternalServerError, true
+}
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/octopustest"
+	"github.com/finwhale/octopus/request"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+type (
+	Book struct {
+		Id       int64
+		Title    string
+		AuthorId int64
+	}
+
+	Author struct {
+		Id   int64
+		Name string
+	}
+
+	Ledger struct {
+		Id       int64
+		TenantId string
+	}
+
+	restDriver struct{}
+
+	restConn struct{}
+)
+
+func init() {
+	sql.Register("octopus_rest", restDriver{})
+}
+
+func (l *Ledger) Query(_ *request.Node) *gorm.DB {
+	sqlDB, _ := sql.Open("octopus_rest", "")
+	db, _ := gorm.Open("mysql", sqlDB)
+
+	return db.Model(&[]Ledger{})
+}
+
+// 연결만 가능하고 SQL 은 실행할 수 없는 드라이버입니다. SQL 을 실행하기 전에 실패해야 하는 요청을 확인할 때 사용합니다.
+func (restDriver) Open(string) (driver.Conn, error) {
+	return restConn{}, nil
+}
+
+func (restConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("Not supported.")
+}
+
+func (restConn) Close() error {
+	return nil
+}
+
+func (restConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("Not supported.")
+}
+
+func setUpRest(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "rest")
+	assert.Nil(t, err)
+
+	ioutil.WriteFile(path.Join(dir, core.DBFilename), []byte(`{"tables":{
+		"book":{"name":"book","softDelete":"deleted_at","columns":{
+			"id":{"name":"id","type":"int(11)","key":"PRI"},
+			"title":{"name":"title","type":"varchar(100)"},
+			"authorId":{"name":"author_id","type":"int(11)"}}},
+		"author":{"name":"author","columns":{
+			"id":{"name":"id","type":"int(11)","key":"PRI"},
+			"name":{"name":"name","type":"varchar(100)"}}},
+		"ledger":{"name":"ledger","tenant":"tenant_id","columns":{
+			"id":{"name":"id","type":"int(11)","key":"PRI"},
+			"tenantId":{"name":"tenant_id","type":"varchar(100)"}}}}}`), 0644)
+
+	projectDir := core.GetProjectDir()
+	core.SetProjectDir(dir)
+	core.GetSchema(true)
+
+	getFunc, getAllFunc, newFunc := request.GetFunc, request.GetAllFunc, request.NewFunc
+	octopustest.RegisterModels(&Book{}, &Author{}, &Ledger{})
+
+	return func() {
+		request.GetFunc, request.GetAllFunc, request.NewFunc = getFunc, getAllFunc, newFunc
+		core.SetProjectDir(projectDir)
+		core.GetSchema(true)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestNewRestRequest_List(t *testing.T) {
+	defer setUpRest(t)()
+
+	query, _ := url.ParseQuery("title[like]=Go%25&id[in]=1,2&author.name=kim&authorId=3&authorId=4&_order=-id,author.name&_limit=10&_offset=20&_withDeleted=true")
+	r, err := newRestRequest(request.GET, "books", "", query, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "query", r.Operation)
+	assert.Equal(t, "bookList", r.Node.Name)
+	assert.Equal(t, "Book", r.Node.Type)
+	assert.True(t, r.Node.IsList)
+	assert.Equal(t, map[string]interface{}{
+		"title":    map[string]interface{}{"like": "Go%"},
+		"id":       map[string]interface{}{"in": []string{"1", "2"}},
+		"authorId": map[string]interface{}{"in": []string{"3", "4"}},
+		"author":   map[string]interface{}{"_object": true, "name": map[string]interface{}{"eq": "kim"}},
+	}, r.Node.Args[request.WHERE])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "DESC"},
+		map[string]interface{}{"author": map[string]interface{}{"_object": true, "name": "ASC"}},
+	}, r.Node.Args[request.ORDER])
+	assert.Equal(t, 10.0, r.Node.Args[request.LIMIT])
+	assert.Equal(t, 20.0, r.Node.Args[request.OFFSET])
+	assert.Equal(t, true, r.Node.Args[request.WITH_DELETED])
+
+	for _, name := range []string{"id", "title", "authorId", "_count", "_limit", "_offset"} {
+		assert.NotNil(t, r.Node.Fields[name], name)
+	}
+}
+
+func TestNewRestRequest_Get(t *testing.T) {
+	defer setUpRest(t)()
+
+	query, _ := url.ParseQuery("_fields=id,title")
+	r, err := newRestRequest(request.GET, "book", "7", query, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "book", r.Node.Name)
+	assert.False(t, r.Node.IsList)
+	assert.Equal(t, map[string]interface{}{"id": map[string]interface{}{"eq": "7"}}, r.Node.Args[request.WHERE])
+	assert.Len(t, r.Node.Fields, 2)
+}
+
+func TestNewRestRequest_Mutation(t *testing.T) {
+	defer setUpRest(t)()
+
+	r, err := newRestRequest(request.CREATE, "books", "", url.Values{}, map[string]interface{}{"title": "Go"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, request.MUTATION, r.Operation)
+	assert.Equal(t, "createBook", r.Node.Name)
+	assert.Equal(t, map[string]interface{}{"title": "Go"}, r.Node.Args)
+
+	r, err = newRestRequest(request.UPDATE, "books", "7", url.Values{}, map[string]interface{}{"title": "Rust"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "updateBook", r.Node.Name)
+	assert.Equal(t, map[string]interface{}{"id": "7", "title": "Rust"}, r.Node.Args)
+
+	r, err = newRestRequest(request.DELETE, "books", "7", url.Values{}, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "deleteBook", r.Node.Name)
+	assert.Equal(t, map[string]interface{}{"id": "7"}, r.Node.Args)
+}
+
+func TestRestHandler_Errors(t *testing.T) {
+	defer setUpRest(t)()
+
+	e := echo.New()
+	registerRest(e)
+
+	for target, expected := range map[string]int{
+		"/rest/publishers":               http.StatusNotFound,
+		"/rest/books?price=1":            http.StatusBadRequest,
+		"/rest/books?title[unknown]=1":   http.StatusBadRequest,
+		"/rest/books?_limit=ten":         http.StatusBadRequest,
+		"/rest/books?_order=-price":      http.StatusBadRequest,
+		"/rest/books?publisher.name=kim": http.StatusBadRequest,
+		"/rest/books?_unknown=1":         http.StatusBadRequest,
+		"/rest/books?title[nil]=maybe":   http.StatusBadRequest,
+		"/rest/books?_fields=id,secret":  http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		assert.Equal(t, expected, rec.Code, target)
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/rest/books", strings.NewReader("[1, 2]")))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRestHandler_TenantHeader(t *testing.T) {
+	defer setUpRest(t)()
+
+	config := &core.Config{}
+	config.Tenant.Column = "tenant_id"
+	config.Tenant.Header = "X-Tenant-Id"
+	defer setUpAuth(config)()
+
+	e := echo.New()
+	registerRest(e)
+
+	// 익명 사용자가 보낸 테넌트 헤더로는 다른 테넌트의 데이터에 접근할 수 없습니다.
+	req := httptest.NewRequest(http.MethodGet, "/rest/ledgers", nil)
+	req.Header.Set("X-Tenant-Id", "acme")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "The tenant is required to access `Ledger`."), rec.Body.String())
+	assert.Equal(t, "acme", req.Header.Get("X-Tenant-Id"))
+
+	// 프록시가 사용자를 전달하는 경우에는 테넌트 헤더도 프록시가 보낸 것으로 믿습니다.
+	config.Rest.UserHeader = "X-User-Id"
+	c := e.NewContext(req, httptest.NewRecorder())
+	assert.Equal(t, "acme", restHeader(c).Get("X-Tenant-Id"))
+}
+
+func TestRestHandler_UnexpectedError(t *testing.T) {
+	defer setUpRest(t)()
+
+	e := echo.New()
+	registerRest(e)
+
+	// 데이터베이스가 설정되지 않은 경우처럼 예상하지 못한 오류의 내용은 응답에 담지 않습니다.
+	req := httptest.NewRequest(http.MethodGet, "/rest/books", nil)
+	req.Header.Set(request.REQUEST_ID, "abc")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "An unexpected error occurred. (request id: abc)"), rec.Body.String())
+	assert.False(t, strings.Contains(rec.Body.String(), "SetDB"), rec.Body.String())
+}
+
+func TestRestStatus(t *testing.T) {
+	status, failed := restStatus(&request.Result{Data: map[string]interface{}{"id": 1}})
+	assert.False(t, failed)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, failed = restStatus(request.ErrorResult("updateBook", &request.MutationError{
+		Errors: []map[string]interface{}{{"key": "updateBook", "code": 404, "message": "Not found."}},
+	}))
+	assert.True(t, failed)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, failed = restStatus(request.ErrorResult("book", os.ErrInvalid))
+	assert.True(t, failed)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
@@ -1,26 +1,75 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/finwhale/octopus/core"
 	"github.com/finwhale/octopus/farmer"
+	"github.com/finwhale/octopus/logging"
+	"github.com/finwhale/octopus/metrics"
 	"github.com/finwhale/octopus/request"
+	"github.com/finwhale/octopus/tracing"
 	"github.com/labstack/echo"
 	"github.com/labstack/echo/middleware"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 )
 
+// 종료할 때 진행 중인 요청을 기다리는 기본 시간입니다.
+const DefaultDrainTimeout = 30 * time.Second
+
 func Run(env string, port string) {
-	adapter, dbUrl, schemaName, charset, maxOpenConns, plural, logMode := core.GetSchemaInfo(env, true)
-	core.SetDB(adapter, dbUrl, schemaName, charset, maxOpenConns, plural, logMode)
+	core.SetDBByEnv(env)
+	metrics.Register()
+	logging.Register()
 
 	e := echo.New()
 	e.Use(middleware.Recover())
+	e.Use(requestId)
+
+	tracer := tracing.Register()
+	if tracer != nil {
+		e.Use(tracer.Middleware)
+	}
+
+	e.GET("/healthz", healthz)
+	e.GET("/readyz", readyz(env))
+
+	e.GET("/metrics", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+		metrics.DefaultRegistry.Write(c.Response())
+
+		return nil
+	})
+
+	registerRest(e)
+	e.POST("/export", export)
 
 	e.POST("/", func(c echo.Context) error {
+		body, err := ioutil.ReadAll(c.Request().Body)
+
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		// 배열 형태의 요청은 일괄 요청으로 처리합니다.
+		if isBatch(body) {
+			return execBatch(c, body)
+		}
+
 		r := new(request.Request)
 
-		c.Bind(&r)
+		if err := json.Unmarshal(body, &r); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
 		r.Header = c.Request().Header
 		result := farmer.Exec(r)
 
@@ -28,5 +77,82 @@ func Run(env string, port string) {
 	})
 
 	fmt.Printf("[%v] ", env)
-	e.Logger.Fatal(e.Start(fmt.Sprintf(":%v", port)))
+
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%v", port)); err != nil && err != http.ErrServerClosed {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	// SIGTERM 또는 SIGINT 를 받으면 /readyz 를 실패시키고, 로드 밸런서가 이를 확인할 수 있도록 server.drainDelay 동안 요청을 계속 받은 후
+	// 새로운 요청을 받지 않고 진행 중인 요청이 끝나기를 기다린 후 종료합니다.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGTERM, os.Interrupt)
+	<-quit
+
+	atomic.StoreInt32(&draining, 1)
+	time.Sleep(time.Duration(core.GetConfig(false).Server.DrainDelay) * time.Second)
+
+	timeout := DefaultDrainTimeout
+	if seconds := core.GetConfig(false).Server.DrainTimeout; seconds > 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := e.Shutdown(ctx); err != nil {
+		e.Logger.Error(err)
+	}
+
+	if tracer != nil {
+		tracer.Close()
+	}
+
+	core.CloseDB()
+}
+
+func execBatch(c echo.Context, body []byte) error {
+	var rs []*request.Request
+
+	if err := json.Unmarshal(body, &rs); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	batch := core.GetConfig(false).Batch
+
+	if batch.MaxSize > 0 && len(rs) > batch.MaxSize {
+		return echo.NewHTTPError(
+			http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("A batch can contain up to %v requests. current %v.", batch.MaxSize, len(rs)),
+		)
+	}
+
+	for _, r := range rs {
+		if r != nil {
+			r.Header = c.Request().Header
+		}
+	}
+
+	return c.JSON(http.StatusOK, farmer.ExecBatch(rs, batch.Concurrency))
+}
+
+// 요청 ID 를 요청과 응답의 헤더에 설정합니다. 클라이언트가 보낸 ID 가 있다면 그대로 사용합니다.
+func requestId(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Request().Header.Get(request.REQUEST_ID)
+
+		if id == "" {
+			id = logging.NewRequestId()
+			c.Request().Header.Set(request.REQUEST_ID, id)
+		}
+
+		c.Response().Header().Set(request.REQUEST_ID, id)
+
+		return next(c)
+	}
+}
+
+func isBatch(body []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
 }
@@ -0,0 +1,197 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// 끝난 스팬들을 외부로 내보냅니다.
+	Exporter interface {
+		Export(spans []*Span) error
+	}
+
+	// OTLP/HTTP 의 JSON 형식으로 수집기에 스팬을 전송합니다.
+	OTLPExporter struct {
+		Endpoint string            // 예: http://localhost:4318
+		Headers  map[string]string // 인증 등에 필요한 추가 헤더
+		Service  string            // service.name 리소스 속성
+		Client   *http.Client
+	}
+
+	// 로컬 파일에 스팬을 한 줄에 하나씩 OTLP JSON 형태로 저장합니다.
+	FileExporter struct {
+		Path    string
+		Service string
+		mutex   sync.Mutex
+	}
+)
+
+const (
+	DefaultService   = "octopus"
+	instrumentation  = "github.com/finwhale/octopus"
+	otlpTracesPath   = "/v1/traces"
+	otlpStatusError  = 2
+	otlpExportPeriod = 5 * time.Second
+)
+
+func (e *OTLPExporter) Export(spans []*Span) error {
+	body, err := json.Marshal(encodeSpans(e.Service, spans))
+
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(e.Endpoint, "/")
+	if !strings.HasSuffix(url, otlpTracesPath) {
+		url += otlpTracesPath
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := e.Client
+	if client == nil {
+		client = &http.Client{Timeout: otlpExportPeriod}
+	}
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("Failed to export spans to `%v`: %v", url, res.Status)
+	}
+
+	return nil
+}
+
+func (e *FileExporter) Export(spans []*Span) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	f, err := os.OpenFile(e.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	for _, span := range spans {
+		if err = encoder.Encode(encodeSpans(e.Service, []*Span{span})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ------------------------------
+// OTLP JSON
+// ------------------------------
+
+func encodeSpans(service string, spans []*Span) map[string]interface{} {
+	if service == "" {
+		service = DefaultService
+	}
+
+	var encoded []map[string]interface{}
+
+	for _, span := range spans {
+		encoded = append(encoded, encodeSpan(span))
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": encodeAttributes(map[string]interface{}{"service.name": service}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": instrumentation},
+						"spans": encoded,
+					},
+				},
+			},
+		},
+	}
+}
+
+func encodeSpan(span *Span) map[string]interface{} {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	encoded := map[string]interface{}{
+		"traceId":           span.TraceId,
+		"spanId":            span.SpanId,
+		"name":              span.Name,
+		"kind":              span.Kind,
+		"startTimeUnixNano": strconv.FormatInt(span.Start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(span.End.UnixNano(), 10),
+		"attributes":        encodeAttributes(span.Attributes),
+	}
+
+	if span.ParentSpanId != "" {
+		encoded["parentSpanId"] = span.ParentSpanId
+	}
+
+	if span.IsError {
+		encoded["status"] = map[string]interface{}{"code": otlpStatusError, "message": span.Message}
+	}
+
+	return encoded
+}
+
+func encodeAttributes(attributes map[string]interface{}) []map[string]interface{} {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	encoded := []map[string]interface{}{}
+	for _, key := range keys {
+		var value map[string]interface{}
+
+		switch v := attributes[key].(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprintf("%v", v)}
+		}
+
+		encoded = append(encoded, map[string]interface{}{"key": key, "value": value})
+	}
+
+	return encoded
+}
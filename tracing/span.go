@@ -0,0 +1,147 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// 요청 사이에 전달되는 추적 정보입니다. W3C Trace Context 의 traceparent 헤더와 같은 내용을 가집니다.
+	SpanContext struct {
+		TraceId string // 32자리 16진수
+		SpanId  string // 16자리 16진수
+		Sampled bool
+	}
+
+	// 하나의 작업 단위입니다. 끝난 스팬은 익스포터로 전달됩니다.
+	Span struct {
+		SpanContext
+		ParentSpanId string
+		Name         string
+		Kind         int
+		Start        time.Time
+		End          time.Time
+		Attributes   map[string]interface{}
+		IsError      bool
+		Message      string // 오류 메시지
+		mutex        sync.Mutex
+	}
+)
+
+// OTLP 에서 정의한 스팬의 종류입니다.
+const (
+	KIND_INTERNAL = 1
+	KIND_SERVER   = 2
+	KIND_CLIENT   = 3
+)
+
+const TRACEPARENT = "traceparent"
+
+// 부모 스팬의 정보를 이어받아 새로운 스팬을 시작합니다. 부모가 없다면 새로운 추적을 시작합니다.
+func StartSpan(parent SpanContext, name string, kind int) *Span {
+	span := &Span{
+		SpanContext: SpanContext{
+			TraceId: parent.TraceId,
+			SpanId:  randomHex(8),
+			Sampled: parent.Sampled,
+		},
+		ParentSpanId: parent.SpanId,
+		Name:         name,
+		Kind:         kind,
+		Start:        time.Now(),
+		Attributes:   map[string]interface{}{},
+	}
+
+	if !parent.IsValid() {
+		span.TraceId = randomHex(16)
+		span.ParentSpanId = ""
+		span.Sampled = true
+	}
+
+	return span
+}
+
+func (s *Span) SetAttribute(key string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.Attributes[key] = value
+}
+
+func (s *Span) SetError(message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.IsError = true
+	s.Message = message
+}
+
+// ------------------------------
+// Span Context
+// ------------------------------
+
+func (c SpanContext) IsValid() bool {
+	return len(c.TraceId) == 32 && len(c.SpanId) == 16 &&
+		c.TraceId != strings.Repeat("0", 32) && c.SpanId != strings.Repeat("0", 16)
+}
+
+// traceparent 헤더의 값으로 변환합니다.
+func (c SpanContext) String() string {
+	flags := "00"
+
+	if c.Sampled {
+		flags = "01"
+	}
+
+	return fmt.Sprintf("00-%v-%v-%v", c.TraceId, c.SpanId, flags)
+}
+
+// traceparent 헤더의 값을 해석합니다. 올바르지 않은 값이라면 빈 SpanContext 를 반환합니다.
+func ParseTraceParent(value string) SpanContext {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[3]) != 2 {
+		return SpanContext{}
+	}
+
+	if !isHex(parts[0]) || !isHex(parts[1]) || !isHex(parts[2]) || !isHex(parts[3]) {
+		return SpanContext{}
+	}
+
+	flags, _ := hex.DecodeString(parts[3])
+	c := SpanContext{
+		TraceId: strings.ToLower(parts[1]),
+		SpanId:  strings.ToLower(parts[2]),
+		Sampled: flags[0]&1 == 1,
+	}
+
+	if !c.IsValid() {
+		return SpanContext{}
+	}
+
+	return c
+}
+
+// ------------------------------
+// Utils
+// ------------------------------
+
+func randomHex(size int) string {
+	b := make([]byte, size)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+func isHex(value string) bool {
+	_, err := hex.DecodeString(value)
+
+	return err == nil
+}
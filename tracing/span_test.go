@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	c := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.Equal(t, c.TraceId, "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, c.SpanId, "00f067aa0ba902b7")
+	assert.True(t, c.Sampled)
+	assert.Equal(t, c.String(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	assert.False(t, ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00").Sampled)
+	assert.False(t, ParseTraceParent("").IsValid())
+	assert.False(t, ParseTraceParent("00-00000000000000000000000000000000-00f067aa0ba902b7-01").IsValid())
+	assert.False(t, ParseTraceParent("ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").IsValid())
+	assert.False(t, ParseTraceParent("00-xyz-00f067aa0ba902b7-01").IsValid())
+}
+
+func TestStartSpan(t *testing.T) {
+	root := StartSpan(SpanContext{}, "root", KIND_SERVER)
+	assert.True(t, root.IsValid())
+	assert.True(t, root.Sampled)
+	assert.Empty(t, root.ParentSpanId)
+
+	child := StartSpan(root.SpanContext, "child", KIND_INTERNAL)
+	assert.Equal(t, child.TraceId, root.TraceId)
+	assert.Equal(t, child.ParentSpanId, root.SpanId)
+	assert.NotEqual(t, child.SpanId, root.SpanId)
+}
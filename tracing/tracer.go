@@ -0,0 +1,284 @@
+package tracing
+
+import (
+	"fmt"
+	"github.com/finwhale/octopus/core"
+	"github.com/finwhale/octopus/request"
+	"github.com/labstack/echo"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	// 요청, 노드, SQL, 커스텀 메서드, 권한 검증을 스팬으로 기록하는 관찰자입니다.
+	Tracer struct {
+		Exporter Exporter
+		spans    sync.Map // 요청 또는 노드별로 진행 중인 스팬
+		queue    chan *Span
+		done     chan struct{}
+		once     sync.Once
+	}
+)
+
+const (
+	OTLP      = "otlp"
+	FILE      = "file"
+	queueSize = 2048
+	batchSize = 512
+)
+
+// 익스포터로 스팬을 내보내는 추적기를 생성합니다. 스팬은 모아서 주기적으로 내보냅니다.
+func NewTracer(exporter Exporter) *Tracer {
+	t := &Tracer{
+		Exporter: exporter,
+		queue:    make(chan *Span, queueSize),
+		done:     make(chan struct{}),
+	}
+
+	go t.run()
+
+	return t
+}
+
+// `config.yaml`의 tracing 설정으로 추적기를 만들어 요청 관찰자로 등록합니다. 설정이 없다면 nil 을 반환합니다.
+func Register() *Tracer {
+	config := core.GetConfig(false).Tracing
+	var exporter Exporter
+
+	switch config.Exporter {
+	case "":
+		return nil
+	case OTLP:
+		exporter = &OTLPExporter{Endpoint: config.Endpoint, Headers: config.Headers, Service: config.Service}
+	case FILE:
+		exporter = &FileExporter{Path: config.File, Service: config.Service}
+	default:
+		panic(fmt.Errorf("`%v` is not a supported tracing exporter. (%v, %v)", config.Exporter, OTLP, FILE))
+	}
+
+	t := NewTracer(exporter)
+	request.AddObserver(t)
+
+	return t
+}
+
+// 남아있는 스팬들을 모두 내보내고 추적기를 종료합니다.
+func (t *Tracer) Close() {
+	t.once.Do(func() {
+		close(t.queue)
+		<-t.done
+	})
+}
+
+func (t *Tracer) finish(span *Span) {
+	span.End = time.Now()
+
+	if !span.Sampled {
+		return
+	}
+
+	// 큐가 가득 찬 경우 요청을 지연시키지 않도록 스팬을 버립니다.
+	select {
+	case t.queue <- span:
+	default:
+	}
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(otlpExportPeriod)
+	defer ticker.Stop()
+
+	var batch []*Span
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := t.Exporter.Export(batch); err != nil {
+			fmt.Fprintf(os.Stderr, "[tracing] %v\n", err)
+		}
+
+		batch = nil
+	}
+
+	for {
+		select {
+		case span, ok := <-t.queue:
+			if !ok {
+				flush()
+				return
+			}
+
+			batch = append(batch, span)
+
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// ------------------------------
+// HTTP
+// ------------------------------
+
+// HTTP 요청을 서버 스팬으로 기록합니다. 들어온 traceparent 헤더를 이어받고,
+// 요청의 traceparent 헤더를 서버 스팬으로 바꾸어 이후의 스팬들이 서버 스팬의 자식이 되도록 합니다.
+func (t *Tracer) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		parent := ParseTraceParent(req.Header.Get(TRACEPARENT))
+		span := StartSpan(parent, fmt.Sprintf("%v %v", req.Method, req.URL.Path), KIND_SERVER)
+		span.SetAttribute("http.method", req.Method)
+		span.SetAttribute("http.target", req.URL.RequestURI())
+
+		req.Header.Set(TRACEPARENT, span.SpanContext.String())
+		c.Response().Header().Set(TRACEPARENT, span.SpanContext.String())
+
+		err := next(c)
+
+		span.SetAttribute("http.status_code", c.Response().Status)
+		if err != nil {
+			span.SetError(err.Error())
+		}
+
+		t.finish(span)
+
+		return err
+	}
+}
+
+// ------------------------------
+// Observer
+// ------------------------------
+
+func (t *Tracer) ObserveRequest(r *request.Request) func() {
+	var parent SpanContext
+
+	if r.Header != nil {
+		parent = ParseTraceParent(r.Header.Get(TRACEPARENT))
+	}
+
+	name := r.Operation
+	if r.Node != nil {
+		name = fmt.Sprintf("%v %v", r.Operation, r.Node.Name)
+	}
+
+	span := StartSpan(parent, name, KIND_INTERNAL)
+	span.SetAttribute("octopus.operation", r.Operation)
+	span.SetAttribute("octopus.request_id", r.Id())
+
+	if r.UserId != nil {
+		span.SetAttribute("octopus.user_id", r.UserId)
+	}
+
+	t.spans.Store(r, span)
+
+	return func() {
+		span.SetAttribute("octopus.sql_count", r.SQLCount())
+		t.spans.Delete(r)
+		t.finish(span)
+	}
+}
+
+func (t *Tracer) ObserveNode(n *request.Node) func() {
+	span := StartSpan(t.parent(n), n.Path(), KIND_INTERNAL)
+	span.SetAttribute("octopus.node.path", n.Path())
+	span.SetAttribute("octopus.node.type", n.Type)
+	span.SetAttribute("octopus.node.is_list", n.IsList || n.IsPlainList)
+
+	return t.enter(n, span)
+}
+
+func (t *Tracer) ObserveCall(n *request.Node, name string) func() {
+	span := StartSpan(t.spanContext(n), name, KIND_INTERNAL)
+	span.SetAttribute("octopus.node.path", n.Path())
+	span.SetAttribute("octopus.node.type", n.Type)
+
+	return func() {
+		t.finish(span)
+	}
+}
+
+func (t *Tracer) ObserveSQL(n *request.Node, sql string, _ []interface{}, duration time.Duration) {
+	span := StartSpan(t.spanContext(n), "SQL", KIND_CLIENT)
+	span.Start = time.Now().Add(-duration)
+	span.SetAttribute("db.system", core.GetSchema(false).Adapter)
+	span.SetAttribute("db.statement", sql)
+
+	t.finish(span)
+}
+
+func (t *Tracer) ObserveDenial(n *request.Node, key string, code int) {
+	if span, exist := t.spans.Load(n); exist {
+		span.(*Span).SetAttribute("octopus.denied."+key, code)
+	}
+}
+
+func (t *Tracer) ObservePanic(r *request.Request, recovered interface{}) {
+	if span, exist := t.spans.Load(r); exist {
+		span.(*Span).SetError(fmt.Sprintf("%v", recovered))
+	}
+}
+
+// 노드의 스팬을 진행 중으로 등록합니다. 같은 노드가 중첩되어 조회되는 경우를 위해 이전 스팬을 되돌립니다.
+func (t *Tracer) enter(n *request.Node, span *Span) func() {
+	previous, hadPrevious := t.spans.Load(n)
+	t.spans.Store(n, span)
+
+	return func() {
+		if hadPrevious {
+			t.spans.Store(n, previous)
+		} else {
+			t.spans.Delete(n)
+		}
+
+		t.finish(span)
+	}
+}
+
+// 노드의 부모 중 진행 중인 스팬을 찾습니다. 없다면 요청의 스팬을 사용합니다.
+func (t *Tracer) parent(n *request.Node) SpanContext {
+	if n.Parent != nil {
+		return t.spanContext(n.Parent)
+	}
+
+	return t.requestContext(n.Request)
+}
+
+// 노드 또는 가장 가까운 부모 노드의 진행 중인 스팬을 찾습니다.
+func (t *Tracer) spanContext(n *request.Node) SpanContext {
+	for node := n; node != nil; node = node.Parent {
+		if span, exist := t.spans.Load(node); exist {
+			return span.(*Span).SpanContext
+		}
+	}
+
+	if n != nil {
+		return t.requestContext(n.Request)
+	}
+
+	return SpanContext{}
+}
+
+func (t *Tracer) requestContext(r *request.Request) SpanContext {
+	if r == nil {
+		return SpanContext{}
+	}
+
+	if span, exist := t.spans.Load(r); exist {
+		return span.(*Span).SpanContext
+	}
+
+	if r.Header != nil {
+		return ParseTraceParent(r.Header.Get(TRACEPARENT))
+	}
+
+	return SpanContext{}
+}
@@ -0,0 +1,136 @@
+package tracing
+
+import (
+	"encoding/json"
+	"github.com/finwhale/octopus/request"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memoryExporter struct {
+	mutex sync.Mutex
+	spans []*Span
+}
+
+func (e *memoryExporter) Export(spans []*Span) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *memoryExporter) find(name string) *Span {
+	for _, span := range e.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	exporter := &memoryExporter{}
+	tracer := NewTracer(exporter)
+
+	header := http.Header{}
+	header.Set(TRACEPARENT, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r := &request.Request{Operation: "query", Header: header, Node: &request.Node{Name: "books", Type: "Book", Fields: map[string]*request.Node{
+		"author": &request.Node{Name: "author", Type: "User"},
+	}}}
+	r.SetUp()
+	author := r.Node.Fields["author"]
+
+	doneRequest := tracer.ObserveRequest(r)
+	doneBooks := tracer.ObserveNode(r.Node)
+	tracer.ObserveSQL(r.Node, "SELECT * FROM `book`", nil, time.Millisecond)
+	doneAuthor := tracer.ObserveNode(author)
+	tracer.ObserveCall(author, "GetName")()
+	doneAuthor()
+	doneBooks()
+	tracer.ObservePanic(r, "boom")
+	doneRequest()
+	tracer.Close()
+
+	assert.Len(t, exporter.spans, 5)
+
+	query := exporter.find("query books")
+	books := exporter.find("books")
+	sql := exporter.find("SQL")
+	authorSpan := exporter.find("books.author")
+	getName := exporter.find("GetName")
+
+	assert.Equal(t, query.TraceId, "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, query.ParentSpanId, "00f067aa0ba902b7")
+	assert.True(t, query.IsError)
+	assert.Equal(t, books.ParentSpanId, query.SpanId)
+	assert.Equal(t, sql.ParentSpanId, books.SpanId)
+	assert.Equal(t, sql.Kind, KIND_CLIENT)
+	assert.Equal(t, authorSpan.ParentSpanId, books.SpanId)
+	assert.Equal(t, getName.ParentSpanId, authorSpan.SpanId)
+	assert.Equal(t, authorSpan.Attributes["octopus.node.type"], "User")
+}
+
+func TestTracer_Unsampled(t *testing.T) {
+	exporter := &memoryExporter{}
+	tracer := NewTracer(exporter)
+
+	header := http.Header{}
+	header.Set(TRACEPARENT, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	tracer.ObserveRequest(&request.Request{Operation: "query", Header: header})()
+	tracer.Close()
+
+	assert.Empty(t, exporter.spans)
+}
+
+func TestOTLPExporter_Export(t *testing.T) {
+	var body map[string]interface{}
+	var contentType string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/traces")
+		contentType = r.Header.Get("Content-Type")
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer server.Close()
+
+	span := StartSpan(SpanContext{}, "books", KIND_INTERNAL)
+	span.SetAttribute("octopus.node.is_list", true)
+	span.End = span.Start.Add(time.Millisecond)
+
+	exporter := &OTLPExporter{Endpoint: server.URL}
+	assert.Nil(t, exporter.Export([]*Span{span}))
+	assert.Equal(t, contentType, "application/json")
+
+	resourceSpans := body["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	scopeSpans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})
+	encoded := scopeSpans["spans"].([]interface{})[0].(map[string]interface{})
+
+	assert.Equal(t, encoded["traceId"], span.TraceId)
+	assert.Equal(t, encoded["name"], "books")
+	assert.Equal(t, encoded["attributes"], []interface{}{
+		map[string]interface{}{"key": "octopus.node.is_list", "value": map[string]interface{}{"boolValue": true}},
+	})
+}
+
+func TestFileExporter_Export(t *testing.T) {
+	dir, err := ioutil.TempDir("", "octopus")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	exporter := &FileExporter{Path: path.Join(dir, "traces.jsonl")}
+	assert.Nil(t, exporter.Export([]*Span{StartSpan(SpanContext{}, "a", KIND_INTERNAL), StartSpan(SpanContext{}, "b", KIND_INTERNAL)}))
+
+	b, err := ioutil.ReadFile(exporter.Path)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Count(string(b), "\n"), 2)
+}